@@ -1,6 +1,11 @@
 package main	
 
-import "fmt"
+import (
+	"fmt"
+
+	"basics/arithmetic_operator/checked"
+	"basics/arithmetic_operator/exact"
+)
 func main() {
 	// Variable Declaration
 	var a, b int = 10, 20
@@ -22,5 +27,34 @@ func main() {
 
 	const p float64 = 22 / 7.0
 	fmt.Println(p)	 
+
+	// Integer division truncates toward zero; the checked package offers
+	// other rounding modes and reports division by zero as an error
+	for _, mode := range []checked.Mode{checked.Truncated, checked.Floored, checked.Euclidean} {
+		q, r, _ := checked.DivMode(-7, 2, mode)
+		fmt.Printf("-7 / 2 (%v) = %d remainder %d\n", mode, q, r)
+	}
+	if _, _, err := checked.Div(a, 0); err != nil {
+		fmt.Println(err)
+	}
+
+	// An exact fraction instead of the float above
+	fraction, _ := checked.Rat(22, 7)
+	fmt.Println(fraction, "=", fraction.FloatString(20))
+
+	// Floats cannot hold 0.1 exactly, so sums drift; a Decimal can
+	x, y := 0.1, 0.2
+	fmt.Println("float64:", x+y)
+	tenCents, twentyCents := exact.MustParseDecimal("0.10"), exact.MustParseDecimal("0.20")
+	fmt.Println("Decimal:", tenCents.Add(twentyCents))
+
+	// Money: 3 items at 19.99 plus 8.25% tax, rounded half to even to cents
+	subtotal := exact.MustParseDecimal("19.99").Mul(exact.DecimalFromInt(3))
+	tax := subtotal.Mul(exact.MustParseDecimal("0.0825")).Round(2)
+	fmt.Printf("subtotal %v + tax %v = %v\n", subtotal, tax, subtotal.Add(tax))
+
+	// A Rational keeps 22/7 as a fraction and prints it to any precision
+	pi := exact.MustParseRational("22/7")
+	fmt.Printf("%v = %.12f\n", pi, pi)
 	
 }
\ No newline at end of file
@@ -0,0 +1,65 @@
+// Package checked does integer arithmetic that reports division by zero
+// and overflow as errors instead of panicking or silently wrapping around.
+package checked
+
+import "errors"
+
+var (
+	ErrDivideByZero = errors.New("checked: division by zero")
+	ErrOverflow     = errors.New("checked: integer overflow")
+)
+
+// Integer is any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// minusOne returns -1 as a T. For unsigned types it wraps to the maximum.
+func minusOne[T Integer]() T {
+	var zero T
+	return zero - 1
+}
+
+// signed reports whether T is a signed type.
+func signed[T Integer]() bool {
+	return minusOne[T]() < 0
+}
+
+// isMin reports whether a is the most negative value of a signed type: the
+// only non-zero value that is its own negation.
+func isMin[T Integer](a T) bool {
+	return a != 0 && a == -a
+}
+
+// Add returns a + b, or ErrOverflow if the sum does not fit in T.
+func Add[T Integer](a, b T) (T, error) {
+	s := a + b
+	if signed[T]() && (b > 0 && s < a || b < 0 && s > a) || !signed[T]() && s < a {
+		return 0, ErrOverflow
+	}
+	return s, nil
+}
+
+// Sub returns a - b, or ErrOverflow if the difference does not fit in T.
+func Sub[T Integer](a, b T) (T, error) {
+	d := a - b
+	if signed[T]() && (b > 0 && d > a || b < 0 && d < a) || !signed[T]() && b > a {
+		return 0, ErrOverflow
+	}
+	return d, nil
+}
+
+// Mul returns a * b, or ErrOverflow if the product does not fit in T.
+func Mul[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	p := a * b
+	// Dividing back catches every wrap-around except MinInt * -1, where the
+	// division itself would overflow
+	if signed[T]() && (isMin(a) && b == minusOne[T]() || isMin(b) && a == minusOne[T]()) || p/b != a {
+		return 0, ErrOverflow
+	}
+	return p, nil
+}
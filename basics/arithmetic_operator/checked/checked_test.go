@@ -0,0 +1,221 @@
+package checked
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAddSub(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(a, b int64) (int64, error)
+		a, b int64
+		want int64
+		err  error
+	}{
+		{"Add", Add[int64], math.MaxInt64, 0, math.MaxInt64, nil},
+		{"Add", Add[int64], math.MaxInt64, 1, 0, ErrOverflow},
+		{"Add", Add[int64], math.MinInt64, -1, 0, ErrOverflow},
+		{"Add", Add[int64], math.MinInt64, math.MaxInt64, -1, nil},
+		{"Sub", Sub[int64], math.MinInt64, 1, 0, ErrOverflow},
+		{"Sub", Sub[int64], 0, math.MinInt64, 0, ErrOverflow},
+		{"Sub", Sub[int64], -1, math.MinInt64, math.MaxInt64, nil},
+		{"Sub", Sub[int64], math.MaxInt64, -1, 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		got, err := tt.op(tt.a, tt.b)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("%s(%d, %d) = %d, %v; want %d, %v", tt.name, tt.a, tt.b, got, err, tt.want, tt.err)
+		}
+	}
+
+	if _, err := Add[uint8](255, 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("uint8 255 + 1: %v", err)
+	}
+	if _, err := Sub[uint8](0, 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("uint8 0 - 1: %v", err)
+	}
+	if v, err := Sub[uint64](math.MaxUint64, math.MaxUint64); v != 0 || err != nil {
+		t.Errorf("uint64 Max - Max = %d, %v", v, err)
+	}
+}
+
+// exhaustive checks f against exact int arithmetic for every pair of int8
+// and of uint8 values, which covers every boundary of both kinds of type.
+func exhaustive(t *testing.T, name string, f8 func(a, b int8) (int8, error), fu func(a, b uint8) (uint8, error), exact func(a, b int) int) {
+	t.Helper()
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			want := exact(a, b)
+			got, err := f8(int8(a), int8(b))
+			fits := want >= math.MinInt8 && want <= math.MaxInt8
+			if fits && (err != nil || int(got) != want) || !fits && !errors.Is(err, ErrOverflow) {
+				t.Fatalf("int8 %s(%d, %d) = %d, %v; exact %d", name, a, b, got, err, want)
+			}
+		}
+	}
+	for a := 0; a <= math.MaxUint8; a++ {
+		for b := 0; b <= math.MaxUint8; b++ {
+			want := exact(a, b)
+			got, err := fu(uint8(a), uint8(b))
+			fits := want >= 0 && want <= math.MaxUint8
+			if fits && (err != nil || int(got) != want) || !fits && !errors.Is(err, ErrOverflow) {
+				t.Fatalf("uint8 %s(%d, %d) = %d, %v; exact %d", name, a, b, got, err, want)
+			}
+		}
+	}
+}
+
+func TestExhaustive(t *testing.T) {
+	exhaustive(t, "Add", Add[int8], Add[uint8], func(a, b int) int { return a + b })
+	exhaustive(t, "Sub", Sub[int8], Sub[uint8], func(a, b int) int { return a - b })
+	exhaustive(t, "Mul", Mul[int8], Mul[uint8], func(a, b int) int { return a * b })
+}
+
+func TestMul(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+		err        error
+	}{
+		{math.MinInt64, -1, 0, ErrOverflow},
+		{-1, math.MinInt64, 0, ErrOverflow},
+		{math.MinInt64, 1, math.MinInt64, nil},
+		{math.MaxInt64, -1, -math.MaxInt64, nil},
+		{1 << 32, 1 << 31, 0, ErrOverflow},
+		{1 << 31, 1 << 31, 1 << 62, nil},
+		{-(1 << 31), 1 << 32, math.MinInt64, nil},
+		{0, math.MinInt64, 0, nil},
+	}
+	for _, tt := range tests {
+		got, err := Mul(tt.a, tt.b)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("Mul(%d, %d) = %d, %v; want %d, %v", tt.a, tt.b, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestDivMode(t *testing.T) {
+	type qr struct{ q, r int }
+	tests := []struct {
+		a, b                          int
+		truncated, floored, euclidean qr
+	}{
+		{7, 2, qr{3, 1}, qr{3, 1}, qr{3, 1}},
+		{-7, 2, qr{-3, -1}, qr{-4, 1}, qr{-4, 1}},
+		{7, -2, qr{-3, 1}, qr{-4, -1}, qr{-3, 1}},
+		{-7, -2, qr{3, -1}, qr{3, -1}, qr{4, 1}},
+		{6, -3, qr{-2, 0}, qr{-2, 0}, qr{-2, 0}},
+		{-6, 3, qr{-2, 0}, qr{-2, 0}, qr{-2, 0}},
+		{0, -5, qr{0, 0}, qr{0, 0}, qr{0, 0}},
+		{-1, 5, qr{0, -1}, qr{-1, 4}, qr{-1, 4}},
+		{1, -5, qr{0, 1}, qr{-1, -4}, qr{0, 1}},
+		{math.MinInt, 1, qr{math.MinInt, 0}, qr{math.MinInt, 0}, qr{math.MinInt, 0}},
+		{math.MinInt, -2, qr{math.MinInt / -2, 0}, qr{math.MinInt / -2, 0}, qr{math.MinInt / -2, 0}},
+		{math.MinInt, 3, qr{math.MinInt / 3, -2}, qr{math.MinInt/3 - 1, 1}, qr{math.MinInt/3 - 1, 1}},
+		{math.MinInt, math.MaxInt, qr{-1, -1}, qr{-2, math.MaxInt - 1}, qr{-2, math.MaxInt - 1}},
+		{math.MaxInt, math.MinInt, qr{0, math.MaxInt}, qr{-1, -1}, qr{0, math.MaxInt}},
+		{math.MinInt, math.MinInt, qr{1, 0}, qr{1, 0}, qr{1, 0}},
+	}
+	for _, tt := range tests {
+		for mode, want := range map[Mode]qr{Truncated: tt.truncated, Floored: tt.floored, Euclidean: tt.euclidean} {
+			q, r, err := DivMode(tt.a, tt.b, mode)
+			if err != nil || q != want.q || r != want.r {
+				t.Errorf("DivMode(%d, %d, %v) = %d, %d, %v; want %d, %d", tt.a, tt.b, mode, q, r, err, want.q, want.r)
+			}
+		}
+	}
+
+	for _, mode := range []Mode{Truncated, Floored, Euclidean} {
+		if _, _, err := DivMode(math.MinInt, -1, mode); !errors.Is(err, ErrOverflow) {
+			t.Errorf("DivMode(MinInt, -1, %v) error = %v, want ErrOverflow", mode, err)
+		}
+		if _, _, err := DivMode(int8(math.MinInt8), -1, mode); !errors.Is(err, ErrOverflow) {
+			t.Errorf("DivMode(MinInt8, -1, %v) error = %v, want ErrOverflow", mode, err)
+		}
+		if _, _, err := DivMode(5, 0, mode); !errors.Is(err, ErrDivideByZero) {
+			t.Errorf("DivMode(5, 0, %v) error = %v, want ErrDivideByZero", mode, err)
+		}
+		if _, _, err := DivMode[uint](0, 0, mode); !errors.Is(err, ErrDivideByZero) {
+			t.Errorf("DivMode[uint](0, 0, %v) error = %v, want ErrDivideByZero", mode, err)
+		}
+		// Unsigned types have no negative operands, so every mode agrees
+		if q, r, err := DivMode[uint8](255, 7, mode); q != 36 || r != 3 || err != nil {
+			t.Errorf("DivMode[uint8](255, 7, %v) = %d, %d, %v", mode, q, r, err)
+		}
+	}
+	if q, r, err := Div(-7, 2); q != -3 || r != -1 || err != nil {
+		t.Errorf("Div(-7, 2) = %d, %d, %v; want Go's -3, -1", q, r, err)
+	}
+}
+
+// TestDivModeInvariants checks every int8 pair: q*b + r == a, |r| < |b|,
+// and each mode's rule for the sign of r.
+func TestDivModeInvariants(t *testing.T) {
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			for _, mode := range []Mode{Truncated, Floored, Euclidean} {
+				q, r, err := DivMode(int8(a), int8(b), mode)
+				switch {
+				case b == 0:
+					if !errors.Is(err, ErrDivideByZero) {
+						t.Fatalf("%d / 0 (%v): %v", a, mode, err)
+					}
+					continue
+				case a == math.MinInt8 && b == -1:
+					if !errors.Is(err, ErrOverflow) {
+						t.Fatalf("MinInt8 / -1 (%v): %v", mode, err)
+					}
+					continue
+				}
+				qi, ri := int(q), int(r)
+				if err != nil || qi*b+ri != a || abs(ri) >= abs(b) {
+					t.Fatalf("DivMode(%d, %d, %v) = %d, %d, %v", a, b, mode, q, r, err)
+				}
+				if ri != 0 && (mode == Truncated && ri < 0 != (a < 0) ||
+					mode == Floored && ri < 0 != (b < 0) ||
+					mode == Euclidean && ri < 0) {
+					t.Fatalf("DivMode(%d, %d, %v) remainder %d has the wrong sign", a, b, mode, r)
+				}
+			}
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestRat(t *testing.T) {
+	tests := []struct {
+		got  func() (string, error)
+		want string
+	}{
+		{func() (string, error) { r, err := Rat(6, -4); return r.RatString(), err }, "-3/2"},
+		{func() (string, error) { r, err := Rat(0, -4); return r.RatString(), err }, "0"},
+		// The exact answer where DivMode reports overflow
+		{func() (string, error) { r, err := Rat[int64](math.MinInt64, -1); return r.RatString(), err }, "9223372036854775808"},
+		{func() (string, error) { r, err := Rat[int8](math.MinInt8, -1); return r.RatString(), err }, "128"},
+		// The top bit of a large unsigned value is not mistaken for a sign
+		{func() (string, error) { r, err := Rat[uint64](math.MaxUint64, 2); return r.RatString(), err }, "18446744073709551615/2"},
+	}
+	for i, tt := range tests {
+		if got, err := tt.got(); err != nil || got != tt.want {
+			t.Errorf("case %d: %s, %v; want %s", i, got, err, tt.want)
+		}
+	}
+	if _, err := Rat(1, 0); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Rat(1, 0) error = %v", err)
+	}
+}
+
+func TestModeString(t *testing.T) {
+	for mode, want := range map[Mode]string{Truncated: "truncated", Floored: "floored", Euclidean: "euclidean", Mode(7): "Mode(7)"} {
+		if mode.String() != want {
+			t.Errorf("Mode(%d).String() = %q, want %q", int(mode), mode, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package checked
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Mode chooses how division rounds a quotient that is not whole. The modes
+// only differ when the operands have different signs, or in Euclidean
+// mode when the divisor is negative.
+type Mode int
+
+const (
+	// Truncated rounds toward zero, like Go's / and %. The remainder has
+	// the sign of the dividend: -7 / 2 = -3 remainder -1.
+	Truncated Mode = iota
+	// Floored rounds toward negative infinity. The remainder has the sign
+	// of the divisor: -7 / 2 = -4 remainder 1.
+	Floored
+	// Euclidean makes the remainder never negative: -7 / -2 = 4
+	// remainder 1.
+	Euclidean
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Truncated:
+		return "truncated"
+	case Floored:
+		return "floored"
+	case Euclidean:
+		return "euclidean"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Div returns the truncated quotient and remainder of a / b, as Go's / and
+// % would, but returns ErrDivideByZero instead of panicking when b is 0,
+// and ErrOverflow for the most negative value divided by -1, whose
+// quotient does not fit in T.
+func Div[T Integer](a, b T) (q, r T, err error) {
+	return DivMode(a, b, Truncated)
+}
+
+// DivMode is like Div but rounds according to mode. In every mode
+// q*b + r == a and |r| < |b|.
+func DivMode[T Integer](a, b T, mode Mode) (q, r T, err error) {
+	if b == 0 {
+		return 0, 0, ErrDivideByZero
+	}
+	if signed[T]() && isMin(a) && b == minusOne[T]() {
+		return 0, 0, ErrOverflow
+	}
+	q, r = a/b, a%b
+	if r == 0 {
+		return q, r, nil
+	}
+	// |b| >= 2 here, so |q| is at most half the range and adjusting it by
+	// one cannot overflow
+	switch mode {
+	case Floored:
+		if (r < 0) != (b < 0) {
+			q--
+			r += b
+		}
+	case Euclidean:
+		if r < 0 {
+			if b > 0 {
+				q--
+				r += b
+			} else {
+				q++
+				r -= b
+			}
+		}
+	}
+	return q, r, nil
+}
+
+// Rat returns a / b exactly as a rational number, or ErrDivideByZero.
+func Rat[T Integer](a, b T) (*big.Rat, error) {
+	if b == 0 {
+		return nil, ErrDivideByZero
+	}
+	return new(big.Rat).SetFrac(toBig(a), toBig(b)), nil
+}
+
+// toBig converts a to a big.Int without losing the top bit of large
+// unsigned values.
+func toBig[T Integer](a T) *big.Int {
+	if signed[T]() {
+		return big.NewInt(int64(a))
+	}
+	return new(big.Int).SetUint64(uint64(a))
+}
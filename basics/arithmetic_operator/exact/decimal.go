@@ -0,0 +1,249 @@
+package exact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is a fixed-point decimal number: an integer count of units of
+// 10**-scale. The scale is the number of digits after the point, so 19.90
+// is 1990 units at scale 2. The zero value is 0 at scale 0.
+//
+// Add, Sub and Mul are exact, so their results may have a larger scale
+// than their operands. Div and Round take the scale to round to, and round
+// half to even: 0.125 rounds to 0.12 and 0.135 to 0.14, which avoids the
+// upward drift of always rounding halves up over many transactions.
+type Decimal struct {
+	units *big.Int // nil means 0; never modified once set
+	scale int
+}
+
+// NewDecimal returns units * 10**-scale; NewDecimal(1999, 2) is 19.99.
+// A negative scale is treated as 0.
+func NewDecimal(units int64, scale int) Decimal {
+	return Decimal{units: big.NewInt(units), scale: max(scale, 0)}
+}
+
+// DecimalFromInt returns i at scale 0.
+func DecimalFromInt(i int64) Decimal {
+	return NewDecimal(i, 0)
+}
+
+// maxExponent bounds the exponents ParseDecimal accepts, so that a short
+// input such as "1e999999999" cannot ask for a billion digits.
+const maxExponent = 1000
+
+// ParseDecimal parses a number such as "19.99", "-0.5" or "+3". The scale
+// is the number of digits written after the point, so "1.50" has scale 2.
+// An exponent of at most maxExponent moves the point, as in JSON: "1.5e-3"
+// is 0.0015 at scale 4, and "1.5E2" is 150 at scale 0. Spaces and other
+// separators are not accepted.
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e < -maxExponent || e > maxExponent {
+			return Decimal{}, fmt.Errorf("%w: decimal %q", ErrSyntax, s)
+		}
+		mantissa, exponent = s[:i], e
+	}
+	digits := mantissa
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		digits = digits[1:]
+	}
+	whole, frac, _ := strings.Cut(digits, ".")
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return Decimal{}, fmt.Errorf("%w: decimal %q", ErrSyntax, s)
+	}
+	units, _ := new(big.Int).SetString(whole+frac, 10)
+	if mantissa[0] == '-' {
+		units.Neg(units)
+	}
+	scale := len(frac) - exponent
+	if scale < 0 {
+		units.Mul(units, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{units: units, scale: scale}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s is invalid. It is
+// meant for constants in source code.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// decimalFromRat rounds r half to even at the given scale.
+func decimalFromRat(r *big.Rat, scale int) Decimal {
+	scale = max(scale, 0)
+	n := new(big.Int).Mul(r.Num(), pow10(scale))
+	return Decimal{units: roundHalfEven(n, r.Denom()), scale: scale}
+}
+
+// bigUnits returns d's units, treating nil as 0.
+func (d Decimal) bigUnits() *big.Int {
+	if d.units == nil {
+		return new(big.Int)
+	}
+	return d.units
+}
+
+// rescale returns d's units at a scale of at least d's, which is exact.
+func (d Decimal) rescale(scale int) *big.Int {
+	return new(big.Int).Mul(d.bigUnits(), pow10(scale-d.scale))
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Add returns d + e at the larger of their scales.
+func (d Decimal) Add(e Decimal) Decimal {
+	scale := max(d.scale, e.scale)
+	return Decimal{units: new(big.Int).Add(d.rescale(scale), e.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - e at the larger of their scales.
+func (d Decimal) Sub(e Decimal) Decimal {
+	return d.Add(e.Neg())
+}
+
+// Mul returns d * e exactly, at the sum of their scales.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{units: new(big.Int).Mul(d.bigUnits(), e.bigUnits()), scale: d.scale + e.scale}
+}
+
+// Div returns d / e rounded half to even at the given scale, or
+// ErrDivideByZero.
+func (d Decimal) Div(e Decimal, scale int) (Decimal, error) {
+	if e.Sign() == 0 {
+		return Decimal{}, ErrDivideByZero
+	}
+	return decimalFromRat(new(big.Rat).Quo(d.Rat(), e.Rat()), scale), nil
+}
+
+// Round returns d rounded half to even at the given scale. A scale larger
+// than d's pads it with zeros.
+func (d Decimal) Round(scale int) Decimal {
+	return decimalFromRat(d.Rat(), scale)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{units: new(big.Int).Neg(d.bigUnits()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{units: new(big.Int).Abs(d.bigUnits()), scale: d.scale}
+}
+
+// Sign returns -1, 0 or +1 as d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	return d.bigUnits().Sign()
+}
+
+// Cmp compares the values of d and e, ignoring scale, and returns -1, 0 or
+// +1 as d is less than, equal to or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	scale := max(d.scale, e.scale)
+	return d.rescale(scale).Cmp(e.rescale(scale))
+}
+
+// Equal reports whether d and e have the same value; 1.5 equals 1.50.
+func (d Decimal) Equal(e Decimal) bool {
+	return d.Cmp(e) == 0
+}
+
+// Rat returns d as an exact fraction.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.bigUnits(), pow10(d.scale))
+}
+
+// Rational returns d as a Rational.
+func (d Decimal) Rational() Rational {
+	return Rational{r: d.Rat()}
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// String returns d with exactly Scale digits after the point.
+func (d Decimal) String() string {
+	return d.fixed(-1)
+}
+
+// fixed formats d with prec digits after the point, rounding if needed,
+// or with its own scale if prec is negative.
+func (d Decimal) fixed(prec int) string {
+	if prec >= 0 && prec != d.scale {
+		d = d.Round(prec)
+	}
+	units := d.bigUnits()
+	digits := new(big.Int).Abs(units).String()
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	sign := ""
+	if units.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	point := len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Format implements fmt.Formatter. %v and %s print String, %.2f rounds half
+// to even to 2 places, and %e and %g format d's float64 value.
+func (d Decimal) Format(s fmt.State, verb rune) {
+	format(d, s, verb)
+}
+
+// MarshalJSON encodes d as a JSON string such as "19.90", so that readers
+// that parse JSON numbers as float64 cannot lose precision or the scale.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a JSON string or number in any form ParseDecimal
+// does. Like encoding/json with other types, it leaves d unchanged for null.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+	if s == "null" {
+		return nil
+	}
+	if len(s) > 0 && s[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,233 @@
+package exact
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  string // String of the result
+		scale int
+	}{
+		{"19.99", "19.99", 2},
+		{"1.50", "1.50", 2},
+		{"-0.5", "-0.5", 1},
+		{"+3", "3", 0},
+		{".5", "0.5", 1},
+		{"5.", "5", 0},
+		{"-0", "0", 0},
+		{"007.10", "7.10", 2},
+		{"123456789012345678901234567890.123", "123456789012345678901234567890.123", 3},
+		{"1e3", "1000", 0},
+		{"1.5E2", "150", 0},
+		{"1.5e-3", "0.0015", 4},
+		{"-2.50e+1", "-25.0", 1},
+		{"25e-1", "2.5", 1},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if err != nil || d.String() != tt.want || d.Scale() != tt.scale {
+			t.Errorf("ParseDecimal(%q) = %v (scale %d), %v; want %s (scale %d)", tt.in, d, d.Scale(), err, tt.want, tt.scale)
+		}
+	}
+
+	for _, in := range []string{"", "+", "-", ".", "abc", "1.2.3", "1,000", " 1", "1 ", "--1", "1e", "e5", "1e1.5", "1e5000", "0x10", "½"} {
+		if _, err := ParseDecimal(in); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ParseDecimal(%q) error = %v, want ErrSyntax", in, err)
+		}
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	// Halves go to the even neighbour; everything else to the nearest
+	tests := []struct {
+		in    string
+		scale int
+		want  string
+	}{
+		{"0.125", 2, "0.12"},
+		{"0.135", 2, "0.14"},
+		{"0.1251", 2, "0.13"},
+		{"-0.125", 2, "-0.12"},
+		{"-0.135", 2, "-0.14"},
+		{"2.5", 0, "2"},
+		{"3.5", 0, "4"},
+		{"-2.5", 0, "-2"},
+		{"-3.5", 0, "-4"},
+		{"0.5", 0, "0"},
+		{"1.005", 2, "1.00"},
+		{"1.015", 2, "1.02"},
+		{"9.995", 2, "10.00"},
+		{"1.5", 3, "1.500"},
+		{"1.5", -1, "2"},
+	}
+	for _, tt := range tests {
+		if got := MustParseDecimal(tt.in).Round(tt.scale).String(); got != tt.want {
+			t.Errorf("Round(%s, %d) = %s, want %s", tt.in, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	d := MustParseDecimal
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"add keeps the larger scale", d("0.1").Add(d("0.20")), "0.30"},
+		{"add is exact", d("0.1").Add(d("0.2")), "0.3"},
+		{"sub", d("1.00").Sub(d("0.01")), "0.99"},
+		{"sub below zero", d("1").Sub(d("2.5")), "-1.5"},
+		{"mul adds scales", d("19.99").Mul(d("3")), "59.97"},
+		{"mul fractions", d("1.5").Mul(d("0.25")), "0.375"},
+		{"neg", d("1.50").Neg(), "-1.50"},
+		{"abs", d("-1.50").Abs(), "1.50"},
+		{"zero value", Decimal{}.Add(Decimal{}), "0"},
+		{"zero value plus", Decimal{}.Add(d("0.05")), "0.05"},
+		{"from int", DecimalFromInt(-42), "-42"},
+		{"new", NewDecimal(1999, 2), "19.99"},
+		{"new with negative scale", NewDecimal(5, -3), "5"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+
+	divs := []struct {
+		a, b  string
+		scale int
+		want  string
+	}{
+		{"1", "3", 4, "0.3333"},
+		{"2", "3", 2, "0.67"},
+		{"1", "8", 2, "0.12"}, // 0.125, a tie
+		{"3", "8", 2, "0.38"}, // 0.375, a tie
+		{"-1", "8", 2, "-0.12"},
+		{"10", "4", 0, "2"},
+		{"100.00", "7", 2, "14.29"},
+	}
+	for _, tt := range divs {
+		got, err := d(tt.a).Div(d(tt.b), tt.scale)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s / %s at %d = %v, %v; want %s", tt.a, tt.b, tt.scale, got, err, tt.want)
+		}
+	}
+	if _, err := d("1").Div(d("0.00"), 2); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("division by zero: %v", err)
+	}
+	if _, err := d("1").Div(Decimal{}, 2); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("division by the zero value: %v", err)
+	}
+}
+
+func TestDecimalCompare(t *testing.T) {
+	d := MustParseDecimal
+	if !d("1.5").Equal(d("1.50")) || d("1.5").Cmp(d("1.49")) != 1 || d("-1").Cmp(d("0.1")) != -1 {
+		t.Error("Cmp should compare values and ignore scale")
+	}
+	if !(Decimal{}).Equal(d("0.000")) || (Decimal{}).Sign() != 0 || d("-0.01").Sign() != -1 {
+		t.Error("the zero value should equal 0 at any scale")
+	}
+	if got := d("0.1").Add(d("0.2")).Float64(); got != 0.3 {
+		t.Errorf("Float64 = %v, want 0.3", got)
+	}
+	if got := d("1.25").Rat().String(); got != "5/4" {
+		t.Errorf("Rat = %s, want 5/4", got)
+	}
+}
+
+func TestDecimalImmutable(t *testing.T) {
+	a, b := MustParseDecimal("1.10"), MustParseDecimal("2.20")
+	a.Add(b)
+	a.Mul(b)
+	a.Neg()
+	a.Round(0)
+	if a.String() != "1.10" || b.String() != "2.20" {
+		t.Errorf("operands changed to %s and %s", a, b)
+	}
+}
+
+func TestDecimalFormat(t *testing.T) {
+	x := MustParseDecimal("1234.125")
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "1234.125"},
+		{"%s", "1234.125"},
+		{"%.2f", "1234.12"},
+		{"%.0f", "1234"},
+		{"%f", "1234.125"},
+		{"%.5f", "1234.12500"},
+		{"%10.1f|", "    1234.1|"},
+		{"%-10.1f|", "1234.1    |"},
+		{"%+v", "+1234.125"},
+		{"%q", `"1234.125"`},
+		{"%.3e", "1.234e+03"},
+		{"%g", "1234.125"},
+		{"%d", "%!d(exact.Decimal=1234.125)"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, x); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+	if got := fmt.Sprintf("%+.1f", MustParseDecimal("-0.25")); got != "-0.2" {
+		t.Errorf("negative with +: %q", got)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	type price struct {
+		Amount Decimal  `json:"amount"`
+		Tax    *Decimal `json:"tax"`
+	}
+	in := price{Amount: MustParseDecimal("19.90")}
+	data, err := json.Marshal(in)
+	if err != nil || string(data) != `{"amount":"19.90","tax":null}` {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+	var out price
+	if err := json.Unmarshal(data, &out); err != nil || out.Amount.String() != "19.90" || out.Tax != nil {
+		t.Errorf("round trip = %+v, %v", out, err)
+	}
+
+	tests := []struct {
+		json string
+		want string
+	}{
+		{`"0.10"`, "0.10"},
+		{`19.9`, "19.9"},
+		{`-3`, "-3"},
+		{`1e3`, "1000"},
+		{`1.5E-2`, "0.015"},
+		{`"2.5e1"`, "25"},
+		{`null`, "7.77"}, // left alone
+		{` "1.0" `, "1.0"},
+	}
+	for _, tt := range tests {
+		d := MustParseDecimal("7.77")
+		if err := json.Unmarshal([]byte(tt.json), &d); err != nil || d.String() != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, %v; want %s", tt.json, d, err, tt.want)
+		}
+	}
+	for _, bad := range []string{`"abc"`, `true`, `{}`, `[1]`, `""`, `"1,5"`} {
+		var d Decimal
+		if err := json.Unmarshal([]byte(bad), &d); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", bad, d)
+		}
+	}
+	var p price
+	if err := json.Unmarshal([]byte(`{"amount":null,"tax":"0.5"}`), &p); err != nil || p.Tax == nil || p.Tax.String() != "0.5" {
+		t.Errorf("Unmarshal with null amount = %+v, %v", p, err)
+	}
+	if p.Amount.Sign() != 0 {
+		t.Errorf("null amount = %v, want the zero value", p.Amount)
+	}
+}
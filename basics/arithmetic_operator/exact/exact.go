@@ -0,0 +1,89 @@
+// Package exact provides numbers that do arithmetic without the rounding
+// errors of float64: Decimal, a fixed-point decimal suited to money, and
+// Rational, an exact fraction.
+//
+// Both are immutable values. Every operation returns a new number and
+// leaves its operands alone, so they can be copied and shared freely.
+package exact
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+var (
+	ErrDivideByZero = errors.New("exact: division by zero")
+	ErrSyntax       = errors.New("exact: invalid syntax")
+)
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+)
+
+// pow10 returns 10**n for n >= 0.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// roundHalfEven returns n / d rounded to the nearest integer, with ties
+// going to the even neighbour (banker's rounding). d must be positive.
+func roundHalfEven(n, d *big.Int) *big.Int {
+	q, m := new(big.Int).DivMod(n, d, new(big.Int)) // q = floor(n/d), 0 <= m < d
+	switch new(big.Int).Lsh(m, 1).Cmp(d) {
+	case 1:
+		q.Add(q, bigOne)
+	case 0:
+		if q.Bit(0) == 1 {
+			q.Add(q, bigOne)
+		}
+	}
+	return q
+}
+
+// formatter holds what Decimal and Rational share for fmt.Formatter.
+type formatter interface {
+	fmt.Stringer
+	fixed(prec int) string // the value rounded to prec decimal places
+	Float64() float64
+}
+
+// format implements fmt.Formatter for x. %v and %s print x's own form,
+// %f prints it in decimal, rounded half-even if a precision is given, %q
+// quotes the %v form, and other float verbs such as %e and %g go through
+// float64. Width and the '-' flag pad the result.
+func format(x formatter, s fmt.State, verb rune) {
+	var out string
+	switch verb {
+	case 'v', 's':
+		out = x.String()
+	case 'q':
+		out = strconv.Quote(x.String())
+	case 'f', 'F':
+		prec, ok := s.Precision()
+		if !ok {
+			prec = -1
+		}
+		out = x.fixed(prec)
+	case 'e', 'E', 'g', 'G':
+		fmt.Fprintf(s, fmt.FormatString(s, verb), x.Float64())
+		return
+	default:
+		fmt.Fprintf(s, "%%!%c(%T=%s)", verb, x, x.String())
+		return
+	}
+	if s.Flag('+') && out[0] != '-' {
+		out = "+" + out
+	}
+	if w, ok := s.Width(); ok && len(out) < w {
+		pad := fmt.Sprintf("%*s", w-len(out), "")
+		if s.Flag('-') {
+			out += pad
+		} else {
+			out = pad + out
+		}
+	}
+	fmt.Fprint(s, out)
+}
@@ -0,0 +1,188 @@
+package exact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Rational is an exact fraction, always kept in lowest terms with a
+// positive denominator, so 2/-4 is stored as -1/2. The zero value is 0.
+type Rational struct {
+	r *big.Rat // nil means 0; never modified once set
+}
+
+// NewRational returns num/den in lowest terms, or ErrDivideByZero.
+func NewRational(num, den int64) (Rational, error) {
+	if den == 0 {
+		return Rational{}, ErrDivideByZero
+	}
+	return Rational{r: big.NewRat(num, den)}, nil
+}
+
+// RationalFromInt returns i/1.
+func RationalFromInt(i int64) Rational {
+	return Rational{r: new(big.Rat).SetInt64(i)}
+}
+
+// ParseRational parses a fraction such as "22/7" or "-3", or a decimal
+// such as "1.25".
+func ParseRational(s string) (Rational, error) {
+	if num, den, ok := strings.Cut(s, "/"); ok {
+		n, okN := new(big.Int).SetString(num, 10)
+		d, okD := new(big.Int).SetString(den, 10)
+		if !okN || !okD || strings.HasPrefix(den, "+") || strings.HasPrefix(den, "-") {
+			return Rational{}, fmt.Errorf("%w: rational %q", ErrSyntax, s)
+		}
+		if d.Sign() == 0 {
+			return Rational{}, ErrDivideByZero
+		}
+		return Rational{r: new(big.Rat).SetFrac(n, d)}, nil
+	}
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return Rational{}, fmt.Errorf("%w: rational %q", ErrSyntax, s)
+	}
+	return d.Rational(), nil
+}
+
+// MustParseRational is like ParseRational but panics if s is invalid.
+func MustParseRational(s string) Rational {
+	x, err := ParseRational(s)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+// rat returns x's value, treating nil as 0.
+func (x Rational) rat() *big.Rat {
+	if x.r == nil {
+		return new(big.Rat)
+	}
+	return x.r
+}
+
+// Num returns the numerator, which carries the sign.
+func (x Rational) Num() *big.Int {
+	return new(big.Int).Set(x.rat().Num())
+}
+
+// Denom returns the denominator, which is always positive.
+func (x Rational) Denom() *big.Int {
+	return new(big.Int).Set(x.rat().Denom())
+}
+
+// Add returns x + y.
+func (x Rational) Add(y Rational) Rational {
+	return Rational{r: new(big.Rat).Add(x.rat(), y.rat())}
+}
+
+// Sub returns x - y.
+func (x Rational) Sub(y Rational) Rational {
+	return Rational{r: new(big.Rat).Sub(x.rat(), y.rat())}
+}
+
+// Mul returns x * y.
+func (x Rational) Mul(y Rational) Rational {
+	return Rational{r: new(big.Rat).Mul(x.rat(), y.rat())}
+}
+
+// Div returns x / y, or ErrDivideByZero.
+func (x Rational) Div(y Rational) (Rational, error) {
+	if y.Sign() == 0 {
+		return Rational{}, ErrDivideByZero
+	}
+	return Rational{r: new(big.Rat).Quo(x.rat(), y.rat())}, nil
+}
+
+// Inv returns 1/x, or ErrDivideByZero.
+func (x Rational) Inv() (Rational, error) {
+	return RationalFromInt(1).Div(x)
+}
+
+// Neg returns -x.
+func (x Rational) Neg() Rational {
+	return Rational{r: new(big.Rat).Neg(x.rat())}
+}
+
+// Abs returns |x|.
+func (x Rational) Abs() Rational {
+	return Rational{r: new(big.Rat).Abs(x.rat())}
+}
+
+// Sign returns -1, 0 or +1 as x is negative, zero or positive.
+func (x Rational) Sign() int {
+	return x.rat().Sign()
+}
+
+// Cmp returns -1, 0 or +1 as x is less than, equal to or greater than y.
+func (x Rational) Cmp(y Rational) int {
+	return x.rat().Cmp(y.rat())
+}
+
+// Equal reports whether x and y are the same number.
+func (x Rational) Equal(y Rational) bool {
+	return x.Cmp(y) == 0
+}
+
+// Rat returns x as a new big.Rat.
+func (x Rational) Rat() *big.Rat {
+	return new(big.Rat).Set(x.rat())
+}
+
+// Decimal returns x rounded half to even at the given scale.
+func (x Rational) Decimal(scale int) Decimal {
+	return decimalFromRat(x.rat(), scale)
+}
+
+// Float64 returns the float64 nearest to x.
+func (x Rational) Float64() float64 {
+	f, _ := x.rat().Float64()
+	return f
+}
+
+// String returns x as "num/den", or just "num" if x is a whole number.
+func (x Rational) String() string {
+	return x.rat().RatString()
+}
+
+// fixed formats x in decimal with prec digits after the point; a negative
+// prec means 10 digits.
+func (x Rational) fixed(prec int) string {
+	if prec < 0 {
+		prec = 10
+	}
+	return x.Decimal(prec).String()
+}
+
+// Format implements fmt.Formatter. %v and %s print 22/7, %.3f prints
+// 3.143, rounded half to even, and %e and %g format x's float64 value.
+func (x Rational) Format(s fmt.State, verb rune) {
+	format(x, s, verb)
+}
+
+// MarshalJSON encodes x as a JSON string such as "22/7".
+func (x Rational) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.String())
+}
+
+// UnmarshalJSON accepts a JSON string in any form ParseRational does. Like
+// encoding/json with other types, it leaves x unchanged for null.
+func (x *Rational) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("exact: rational must be a JSON string: %w", err)
+	}
+	v, err := ParseRational(s)
+	if err != nil {
+		return err
+	}
+	*x = v
+	return nil
+}
@@ -0,0 +1,157 @@
+package exact
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseRational(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"22/7", "22/7"},
+		{"2/-4", ""}, // the sign belongs on the numerator
+		{"-2/4", "-1/2"},
+		{"+6/3", "2"},
+		{"-3", "-3"},
+		{"1.25", "5/4"},
+		{"1.5e-1", "3/20"},
+		{"0/5", "0"},
+	}
+	for _, tt := range tests {
+		x, err := ParseRational(tt.in)
+		if tt.want == "" {
+			if !errors.Is(err, ErrSyntax) {
+				t.Errorf("ParseRational(%q) = %v, %v; want ErrSyntax", tt.in, x, err)
+			}
+			continue
+		}
+		if err != nil || x.String() != tt.want {
+			t.Errorf("ParseRational(%q) = %v, %v; want %s", tt.in, x, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "/", "1/", "/2", "a/b", "1/+2", "1/2/3", "x", "1 /2"} {
+		if _, err := ParseRational(in); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ParseRational(%q) error = %v, want ErrSyntax", in, err)
+		}
+	}
+	if _, err := ParseRational("1/0"); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("ParseRational(1/0) error = %v, want ErrDivideByZero", err)
+	}
+}
+
+func TestRationalArithmetic(t *testing.T) {
+	r := MustParseRational
+	x, err := NewRational(2, -4)
+	if err != nil || x.String() != "-1/2" || x.Num().Int64() != -1 || x.Denom().Int64() != 2 {
+		t.Errorf("NewRational(2, -4) = %v, %v; want -1/2", x, err)
+	}
+	if _, err := NewRational(1, 0); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("NewRational(1, 0) error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  Rational
+		want string
+	}{
+		{"add", r("1/3").Add(r("1/6")), "1/2"},
+		{"sub", r("1/3").Sub(r("1/2")), "-1/6"},
+		{"mul", r("2/3").Mul(r("9/4")), "3/2"},
+		{"neg", r("2/3").Neg(), "-2/3"},
+		{"abs", r("-2/3").Abs(), "2/3"},
+		{"tenths add up", r("0.1").Add(r("0.2")), "3/10"},
+		{"zero value", Rational{}.Add(RationalFromInt(2)), "2"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+	if q, err := r("1/3").Div(r("2/3")); err != nil || q.String() != "1/2" {
+		t.Errorf("Div = %v, %v", q, err)
+	}
+	if _, err := r("1/3").Div(Rational{}); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Div by zero: %v", err)
+	}
+	if inv, err := r("-3/4").Inv(); err != nil || inv.String() != "-4/3" {
+		t.Errorf("Inv = %v, %v", inv, err)
+	}
+	if _, err := (Rational{}).Inv(); !errors.Is(err, ErrDivideByZero) {
+		t.Errorf("Inv of zero: %v", err)
+	}
+	if !r("2/4").Equal(r("1/2")) || r("1/3").Cmp(r("1/2")) != -1 || r("-1/2").Sign() != -1 {
+		t.Error("comparisons are wrong")
+	}
+
+	// Num and Rat return copies
+	a := r("1/2")
+	a.Num().SetInt64(99)
+	a.Rat().SetInt64(99)
+	if a.String() != "1/2" {
+		t.Errorf("changing a copy changed x to %s", a)
+	}
+}
+
+func TestRationalDecimal(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale int
+		want  string
+	}{
+		{"22/7", 3, "3.143"},
+		{"1/3", 5, "0.33333"},
+		{"1/8", 2, "0.12"},
+		{"3/8", 2, "0.38"},
+		{"-1/8", 2, "-0.12"},
+		{"5/2", 0, "2"},
+	}
+	for _, tt := range tests {
+		if got := MustParseRational(tt.in).Decimal(tt.scale).String(); got != tt.want {
+			t.Errorf("%s.Decimal(%d) = %s, want %s", tt.in, tt.scale, got, tt.want)
+		}
+	}
+	if got := MustParseDecimal("0.75").Rational().String(); got != "3/4" {
+		t.Errorf("Decimal.Rational = %s", got)
+	}
+}
+
+func TestRationalFormat(t *testing.T) {
+	x := MustParseRational("22/7")
+	tests := []struct{ format, want string }{
+		{"%v", "22/7"},
+		{"%s", "22/7"},
+		{"%q", `"22/7"`},
+		{"%.3f", "3.143"},
+		{"%f", "3.1428571429"},
+		{"%8v|", "    22/7|"},
+		{"%.2e", "3.14e+00"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, x); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestRationalJSON(t *testing.T) {
+	data, err := json.Marshal([]Rational{MustParseRational("22/7"), {}, RationalFromInt(-3)})
+	if err != nil || string(data) != `["22/7","0","-3"]` {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+	var back []Rational
+	if err := json.Unmarshal(data, &back); err != nil || len(back) != 3 || back[0].String() != "22/7" {
+		t.Errorf("round trip = %v, %v", back, err)
+	}
+
+	x := MustParseRational("1/2")
+	if err := json.Unmarshal([]byte("null"), &x); err != nil || x.String() != "1/2" {
+		t.Errorf("Unmarshal(null) = %v, %v; want 1/2 left alone", x, err)
+	}
+	for _, bad := range []string{`0.5`, `"1/0"`, `"abc"`, `true`} {
+		var y Rational
+		if err := json.Unmarshal([]byte(bad), &y); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", bad, y)
+		}
+	}
+}
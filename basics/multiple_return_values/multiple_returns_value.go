@@ -28,6 +28,8 @@ import (
 	"strconv" // For string conversion functions
 	"strings" // For string manipulation functions
 	"time"    // For time-related functions
+
+	"basics/arithmetic_operator/checked" // For division that cannot panic
 )
 
 // =====================================================================
@@ -46,6 +48,7 @@ import (
 //   - int: the remainder (a % b)
 //
 // Note: This function will panic if b is 0 (division by zero).
+// checked.Div returns ErrDivideByZero instead.
 func divide(a int, b int) (int, int) {
 	quotient := a / b
 	remainder := a % b
@@ -133,6 +136,11 @@ func main() {
 	onlyQuotient, _ := divide(20, 6)
 	fmt.Printf("  Only using quotient: 20 ÷ 6 = %d\n", onlyQuotient)
 
+	// divide(10, 0) would panic; checked.Div returns an error instead
+	if _, _, err := checked.Div(10, 0); err != nil {
+		fmt.Printf("  checked.Div(10, 0) error: %v\n", err)
+	}
+
 	// Example 2: Handling errors with multiple return values
 	// -----------------------------------------------------
 	printSectionHeader("2. Error Handling Pattern")
@@ -0,0 +1,177 @@
+package crash
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+)
+
+// report returns a small report stamped with the given second, so file
+// names sort in the order the reports are made.
+func report(sec int, value string) *Report {
+	return &Report{
+		Time:  time.Date(2024, 1, 2, 3, 4, sec, 0, time.UTC),
+		Value: value,
+		Type:  "string",
+		Frames: []Frame{
+			{Function: "panic", File: "/go/src/runtime/panic.go", Line: 770},
+			{Function: "main.crash", File: "/src/main.go", Line: 12},
+		},
+	}
+}
+
+func TestWriteLoad(t *testing.T) {
+	r, err := NewReporter(filepath.Join(t.TempDir(), "reports"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := report(0, "boom")
+	path, err := r.Write(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(filepath.Base(path), filePrefix) || filepath.Ext(path) != fileExt {
+		t.Errorf("Write saved to %s", path)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Time.Equal(want.Time) || got.Value != want.Value || len(got.Frames) != len(want.Frames) {
+		t.Errorf("Load = %+v, want %+v", got, want)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+	// Files that are not reports are never listed or removed
+	other := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(other, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewReporter(dir, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written []string
+	for i := range 5 {
+		path, err := r.Write(report(i, "boom"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		written = append(written, path)
+	}
+	paths, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := written[2:]; strings.Join(paths, "\n") != strings.Join(want, "\n") {
+		t.Errorf("after rotation List = %v, want the newest three %v", paths, want)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("rotation touched another file: %v", err)
+	}
+}
+
+func TestKeepZero(t *testing.T) {
+	r := &Reporter{Dir: t.TempDir()}
+	for i := range 4 {
+		if _, err := r.Write(report(i, "boom")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if paths, _ := List(r.Dir); len(paths) != 4 {
+		t.Errorf("Keep 0 left %d reports, want all 4", len(paths))
+	}
+}
+
+func TestListMissingDir(t *testing.T) {
+	if _, err := List(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("List of a missing dir = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestLoadBadJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash-bad.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Load of bad JSON = %v, want an error naming the file", err)
+	}
+}
+
+func crashHere(r *Reporter) {
+	defer r.Recover()
+	panic("crash here")
+}
+
+func TestRecover(t *testing.T) {
+	r := &Reporter{Dir: t.TempDir()}
+	crashHere(r)
+	paths, err := List(r.Dir)
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("List = %v, %v; want one report", paths, err)
+	}
+	rep, err := Load(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Value != "crash here" || rep.Type != "string" {
+		t.Errorf("report of %q (%s)", rep.Value, rep.Type)
+	}
+	if f, ok := rep.Culprit(); !ok || !strings.HasSuffix(f.Function, "crash.crashHere") {
+		t.Errorf("Culprit = %v, %v; want crashHere", f, ok)
+	}
+}
+
+func TestCulprit(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []Frame
+		want   string // "" for no culprit
+	}{
+		{"below panic", report(0, "").Frames, "main.crash"},
+		{"skips runtime", []Frame{
+			{Function: "panic"},
+			{Function: "runtime.panicmem"},
+			{Function: "runtime.sigpanic"},
+			{Function: "main.deref"},
+		}, "main.deref"},
+		{"no panic frame", []Frame{{Function: "main.main"}}, "main.main"},
+		{"only runtime", []Frame{{Function: "panic"}, {Function: "runtime.goexit"}}, ""},
+		{"empty", nil, ""},
+	}
+	for _, tt := range tests {
+		rep := &Report{Frames: tt.frames}
+		f, ok := rep.Culprit()
+		if ok != (tt.want != "") || f.Function != tt.want {
+			t.Errorf("%s: Culprit = %q, %v; want %q", tt.name, f.Function, ok, tt.want)
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	rep := report(5, "boom")
+	if got, want := rep.Summary(), "2024-01-02 03:04:05  string: boom  (in main.crash)"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+	rep.Frames = nil
+	if got := rep.Summary(); !strings.HasSuffix(got, "(in unknown location)") {
+		t.Errorf("Summary without frames = %q", got)
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	rep := NewReport(errors.New("bad"), debug.Stack())
+	if rep.Value != "bad" || rep.Type != "*errors.errorString" {
+		t.Errorf("NewReport value %q of type %s", rep.Value, rep.Type)
+	}
+	if rep.Goroutine == 0 || len(rep.Frames) == 0 || rep.Stack == "" {
+		t.Errorf("NewReport lost the stack: goroutine %d, %d frames", rep.Goroutine, len(rep.Frames))
+	}
+}
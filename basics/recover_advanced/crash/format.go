@@ -0,0 +1,55 @@
+package crash
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"basics/recover_advanced/stacktrace"
+)
+
+// Culprit returns the frame that panicked: the first frame below the call
+// to panic that is not part of the runtime.
+func (rep *Report) Culprit() (Frame, bool) {
+	start := 0
+	for i, f := range rep.Frames {
+		if f.Function == "panic" {
+			start = i + 1
+		}
+	}
+	for _, f := range rep.Frames[start:] {
+		if !(stacktrace.Frame{Function: f.Function}).IsRuntime() {
+			return f, true
+		}
+	}
+	return Frame{}, false
+}
+
+// Summary returns a one-line description of rep for listings.
+func (rep *Report) Summary() string {
+	where := "unknown location"
+	if f, ok := rep.Culprit(); ok {
+		where = f.Function
+	}
+	return fmt.Sprintf("%s  %s: %s  (in %s)", rep.Time.Format("2006-01-02 15:04:05"), rep.Type, rep.Value, where)
+}
+
+// WriteTo prints rep in a human-readable layout.
+func (rep *Report) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "panic: %s (%s)\n", rep.Value, rep.Type)
+	fmt.Fprintf(&b, "time:      %s\n", rep.Time.Format("2006-01-02 15:04:05.000 MST"))
+	fmt.Fprintf(&b, "platform:  %s/%s, %d CPUs\n", rep.GOOS, rep.GOARCH, rep.NumCPU)
+	if rep.Build != nil {
+		fmt.Fprintf(&b, "build:     %s %s (%s)\n", rep.Build.Path, rep.Build.Version, rep.Build.GoVersion)
+	}
+	m := rep.Memory
+	fmt.Fprintf(&b, "memory:    %d KiB in use, %d KiB from OS, %d GCs, %d goroutines\n",
+		m.Alloc/1024, m.Sys/1024, m.NumGC, m.NumGoroutine)
+	fmt.Fprintf(&b, "\ngoroutine %d:\n", rep.Goroutine)
+	for _, f := range rep.Frames {
+		fmt.Fprintf(&b, "  %s\n      %s:%d\n", f.Function, f.File, f.Line)
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,102 @@
+// Package crash writes a JSON report file for each recovered panic, so a
+// crash can be examined after the program has moved on or exited.
+package crash
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"basics/recover_advanced/stacktrace"
+)
+
+// Report is the content of one crash report file.
+type Report struct {
+	Time      time.Time `json:"time"`
+	Value     string    `json:"value"` // the panic value, formatted with %v
+	Type      string    `json:"type"`  // the panic value's type, formatted with %T
+	Goroutine int       `json:"goroutine"`
+	Frames    []Frame   `json:"frames"`
+	Stack     string    `json:"stack"` // the raw stack, as printed by debug.Stack
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	NumCPU    int       `json:"num_cpu"`
+	Build     *Build    `json:"build,omitempty"` // nil if the binary has no build info
+	Memory    Memory    `json:"memory"`
+}
+
+// Frame is one function call in a stack.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Build is the subset of debug.BuildInfo worth keeping in a report.
+type Build struct {
+	GoVersion string            `json:"go_version"`
+	Path      string            `json:"path"`
+	Version   string            `json:"version"`
+	Settings  map[string]string `json:"settings,omitempty"`
+}
+
+// Memory is the subset of runtime.MemStats worth keeping in a report.
+type Memory struct {
+	Alloc        uint64 `json:"alloc"`
+	TotalAlloc   uint64 `json:"total_alloc"`
+	Sys          uint64 `json:"sys"`
+	HeapObjects  uint64 `json:"heap_objects"`
+	NumGC        uint32 `json:"num_gc"`
+	NumGoroutine int    `json:"num_goroutine"`
+}
+
+// NewReport builds a report for a panic with the given value and stack,
+// which is normally the result of debug.Stack in the recovering function.
+func NewReport(value any, stack []byte) *Report {
+	var goroutine int
+	var frames []Frame
+	if gs, err := stacktrace.Parse(stack); err == nil {
+		goroutine = gs[0].ID
+		for _, f := range gs[0].Frames {
+			frames = append(frames, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+	}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return &Report{
+		Time:      time.Now().UTC(),
+		Value:     fmt.Sprint(value),
+		Type:      fmt.Sprintf("%T", value),
+		Goroutine: goroutine,
+		Frames:    frames,
+		Stack:     string(stack),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+		Build:     readBuild(),
+		Memory: Memory{
+			Alloc:        ms.Alloc,
+			TotalAlloc:   ms.TotalAlloc,
+			Sys:          ms.Sys,
+			HeapObjects:  ms.HeapObjects,
+			NumGC:        ms.NumGC,
+			NumGoroutine: runtime.NumGoroutine(),
+		},
+	}
+}
+
+func readBuild() *Build {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	b := &Build{GoVersion: info.GoVersion, Path: info.Main.Path, Version: info.Main.Version}
+	for _, s := range info.Settings {
+		if b.Settings == nil {
+			b.Settings = make(map[string]string)
+		}
+		b.Settings[s.Key] = s.Value
+	}
+	return b
+}
@@ -0,0 +1,117 @@
+package crash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+// filePrefix and fileExt name report files. The timestamp between them
+// sorts in time order, which is what rotation and List rely on.
+const (
+	filePrefix = "crash-"
+	fileExt    = ".json"
+	timeLayout = "20060102T150405.000000000Z"
+)
+
+// Reporter writes crash reports to Dir, keeping at most Keep of them. The
+// oldest reports are deleted when a new one would exceed the limit.
+type Reporter struct {
+	Dir  string
+	Keep int // 0 means keep every report
+}
+
+// NewReporter returns a Reporter for dir, creating it if needed.
+func NewReporter(dir string, keep int) (*Reporter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("crash: %w", err)
+	}
+	return &Reporter{Dir: dir, Keep: keep}, nil
+}
+
+// Write saves rep as a new file and returns its path, then deletes the
+// oldest reports beyond the limit.
+func (r *Reporter) Write(rep *Report) (string, error) {
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("crash: %w", err)
+	}
+	name := fmt.Sprintf("%s%s-%d%s", filePrefix, rep.Time.UTC().Format(timeLayout), os.Getpid(), fileExt)
+	path := filepath.Join(r.Dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("crash: %w", err)
+	}
+	return path, r.rotate()
+}
+
+// rotate deletes the oldest reports so that at most Keep remain.
+func (r *Reporter) rotate() error {
+	if r.Keep <= 0 {
+		return nil
+	}
+	paths, err := List(r.Dir)
+	if err != nil || len(paths) <= r.Keep {
+		return err
+	}
+	var errs []error
+	for _, p := range paths[:len(paths)-r.Keep] {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("crash: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Recover is meant to be deferred. If the surrounding function panics,
+// Recover stops the panic and writes a report for it. Errors writing the
+// report are printed to stderr, since there is no one to return them to.
+//
+//	defer reporter.Recover()
+func (r *Reporter) Recover() {
+	v := recover()
+	if v == nil {
+		return
+	}
+	if _, err := r.Write(NewReport(v, debug.Stack())); err != nil {
+		fmt.Fprintln(os.Stderr, "crash: cannot write report:", err)
+	}
+}
+
+// List returns the paths of the reports in dir, oldest first.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("crash: %w", err)
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), filePrefix) && strings.HasSuffix(e.Name(), fileExt) {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// Load reads the report at path.
+func Load(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("crash: %w", err)
+	}
+	var rep Report
+	if err := json.Unmarshal(data, &rep); err != nil {
+		return nil, fmt.Errorf("crash: %s: %w", path, err)
+	}
+	return &rep, nil
+}
+
+// DefaultDir is where the examples in this repository keep their reports.
+func DefaultDir() string {
+	return filepath.Join(os.TempDir(), "crash-reports")
+}
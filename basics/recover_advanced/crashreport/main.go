@@ -0,0 +1,100 @@
+// Command crashreport lists and prints the crash reports written by the
+// crash package.
+//
+// Usage:
+//
+//	crashreport [-dir DIR] list
+//	crashreport [-dir DIR] show [N | FILE | latest]
+//
+// list prints one numbered line per report, oldest first. show prints a
+// report in full: by its number in the listing, by file name, or the
+// latest one if no argument is given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"basics/recover_advanced/crash"
+)
+
+func main() {
+	dir := flag.String("dir", crash.DefaultDir(), "directory holding the reports")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: crashreport [-dir DIR] list | show [N | FILE | latest]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var err error
+	switch cmd := flag.Arg(0); cmd {
+	case "", "list":
+		err = list(*dir)
+	case "show":
+		err = show(*dir, flag.Arg(1))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "crashreport:", err)
+		os.Exit(1)
+	}
+}
+
+// list prints a numbered summary of every report in dir
+func list(dir string) error {
+	paths, err := crash.List(dir)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Println("no crash reports in", dir)
+		return nil
+	}
+	for i, p := range paths {
+		rep, err := crash.Load(p)
+		if err != nil {
+			fmt.Printf("%3d  %s: %v\n", i+1, filepath.Base(p), err)
+			continue
+		}
+		fmt.Printf("%3d  %s\n", i+1, rep.Summary())
+	}
+	return nil
+}
+
+// show prints the report selected by arg in full
+func show(dir, arg string) error {
+	paths, err := crash.List(dir)
+	if err != nil {
+		return err
+	}
+	var path string
+	switch n, convErr := strconv.Atoi(arg); {
+	case arg == "" || arg == "latest":
+		if len(paths) == 0 {
+			return fmt.Errorf("no crash reports in %s", dir)
+		}
+		path = paths[len(paths)-1]
+	case convErr == nil:
+		if n < 1 || n > len(paths) {
+			return fmt.Errorf("no report number %d; there are %d", n, len(paths))
+		}
+		path = paths[n-1]
+	case filepath.Base(arg) == arg:
+		path = filepath.Join(dir, arg)
+	default:
+		path = arg
+	}
+
+	rep, err := crash.Load(path)
+	if err != nil {
+		return err
+	}
+	fmt.Println(path)
+	_, err = rep.WriteTo(os.Stdout)
+	return err
+}
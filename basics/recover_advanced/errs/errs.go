@@ -0,0 +1,232 @@
+// Package errs provides a structured error type: each error has a kind, an
+// optional application code, key/value context and the stack where it was
+// created, and may wrap a cause.
+//
+// Kinds and codes are errors themselves, so errors.Is can test for them
+// anywhere in a chain:
+//
+//	err := errs.Wrap(sql.ErrNoRows, errs.NotFound, "user not found", "id", 42)
+//	errors.Is(err, errs.NotFound)  // true
+//	errors.Is(err, sql.ErrNoRows)  // true
+//	fmt.Printf("%+v\n", err)       // the whole chain with stacks
+package errs
+
+import (
+	"errors"
+	"runtime"
+)
+
+// Kind is the broad category of an error. A Kind is also an error, so
+// errors.Is(err, errs.NotFound) reports whether any error in err's chain
+// has that kind.
+type Kind int
+
+const (
+	Unknown  Kind = iota // no kind given
+	NotFound             // something asked for does not exist
+	Invalid              // the caller passed bad input
+	Internal             // a bug or failure the caller cannot fix
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NotFound:
+		return "not found"
+	case Invalid:
+		return "invalid"
+	case Internal:
+		return "internal"
+	}
+	return "unknown"
+}
+
+func (k Kind) Error() string {
+	return k.String()
+}
+
+// Code is an application-specific error code, such as "user.missing".
+// Like Kind, it is an error so errors.Is can look for it.
+type Code string
+
+func (c Code) Error() string {
+	return string(c)
+}
+
+// field is one key/value pair of context.
+type field struct {
+	key   string
+	value any
+}
+
+// Error is the error type returned by New and Wrap.
+type Error struct {
+	Kind Kind
+	Code Code
+	Msg  string
+	Err  error // the wrapped cause, or nil
+
+	fields []field
+	stack  []uintptr
+}
+
+// maxDepth limits how many frames are captured per error.
+const maxDepth = 32
+
+// newError captures the stack above its caller's caller.
+func newError(kind Kind, msg string, cause error, kv []any) *Error {
+	pcs := make([]uintptr, maxDepth)
+	n := runtime.Callers(3, pcs)
+	e := &Error{Kind: kind, Msg: msg, Err: cause, stack: pcs[:n]}
+	e.Code, e.fields = pairs(kv)
+	return e
+}
+
+// pairs turns alternating keys and values into fields, taking out any Code
+// on the way. A key that is not a string, or that has no value, is kept
+// under the key "!BADKEY" as slog does.
+func pairs(kv []any) (Code, []field) {
+	var code Code
+	var fs []field
+	for len(kv) > 0 {
+		if c, ok := kv[0].(Code); ok {
+			code = c
+			kv = kv[1:]
+			continue
+		}
+		key, ok := kv[0].(string)
+		if !ok || len(kv) == 1 {
+			fs = append(fs, field{"!BADKEY", kv[0]})
+			kv = kv[1:]
+			continue
+		}
+		fs = append(fs, field{key, kv[1]})
+		kv = kv[2:]
+	}
+	return code, fs
+}
+
+// New returns an error of the given kind with alternating key/value
+// context, capturing the caller's stack. A Code among kv sets the error's
+// code rather than starting a pair:
+//
+//	errs.New(errs.Invalid, "bad email", errs.Code("user.email"), "email", s)
+func New(kind Kind, msg string, kv ...any) *Error {
+	return newError(kind, msg, nil, kv)
+}
+
+// Wrap returns an error of the given kind that wraps err, capturing the
+// caller's stack. It returns nil if err is nil, so it can wrap a result
+// unconditionally. Use Unknown to keep the kind of err. kv is as for New.
+func Wrap(err error, kind Kind, msg string, kv ...any) error {
+	if err == nil {
+		return nil
+	}
+	return newError(kind, msg, err, kv)
+}
+
+// WithCode returns a copy of e with the given code.
+func (e *Error) WithCode(code Code) *Error {
+	c := *e
+	c.Code = code
+	return &c
+}
+
+// With returns a copy of e with more key/value context. As with New, a
+// Code among kv replaces e's code.
+func (e *Error) With(kv ...any) *Error {
+	c := *e
+	code, fs := pairs(kv)
+	if code != "" {
+		c.Code = code
+	}
+	c.fields = append(e.fields[:len(e.fields):len(e.fields)], fs...)
+	return &c
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Msg
+	case e.Msg == "":
+		return e.Err.Error()
+	}
+	return e.Msg + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is e's Kind or Code.
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case Kind:
+		return e.Kind == t
+	case Code:
+		return e.Code != "" && e.Code == t
+	}
+	return false
+}
+
+// Stack returns the frames where e was created, innermost first.
+// It returns nil for an Error that was not made by New or Wrap.
+func (e *Error) Stack() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+	var frames []runtime.Frame
+	iter := runtime.CallersFrames(e.stack)
+	for {
+		f, more := iter.Next()
+		frames = append(frames, f)
+		if !more {
+			return frames
+		}
+	}
+}
+
+// chain calls yield for each *Error in err's chain, outermost first,
+// following single Unwrap methods only.
+func chain(err error, yield func(*Error) bool) {
+	for err != nil {
+		if e, ok := err.(*Error); ok && !yield(e) {
+			return
+		}
+		err = errors.Unwrap(err)
+	}
+}
+
+// KindOf returns the first kind other than Unknown in err's chain.
+func KindOf(err error) Kind {
+	kind := Unknown
+	chain(err, func(e *Error) bool {
+		kind = e.Kind
+		return kind == Unknown
+	})
+	return kind
+}
+
+// CodeOf returns the first code in err's chain, or "".
+func CodeOf(err error) Code {
+	var code Code
+	chain(err, func(e *Error) bool {
+		code = e.Code
+		return code == ""
+	})
+	return code
+}
+
+// Fields returns the key/value context of every error in err's chain. When
+// a key appears more than once, the outermost value wins.
+func Fields(err error) map[string]any {
+	m := make(map[string]any)
+	chain(err, func(e *Error) bool {
+		for _, f := range e.fields {
+			if _, ok := m[f.key]; !ok {
+				m[f.key] = f.value
+			}
+		}
+		return true
+	})
+	return m
+}
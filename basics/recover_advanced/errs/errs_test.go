@@ -0,0 +1,205 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// load fails the way a real lookup would: a NotFound error wrapped in an
+// Internal one with a code.
+func load() error {
+	cause := Wrap(fs.ErrNotExist, NotFound, "file missing", "path", "/etc/app.yaml")
+	return Wrap(cause, Internal, "cannot load config", Code("config.load"), "attempt", 2)
+}
+
+func TestIs(t *testing.T) {
+	err := load()
+	tests := []struct {
+		target error
+		want   bool
+	}{
+		{Internal, true},
+		{NotFound, true}, // the kind of the cause
+		{Invalid, false},
+		{Code("config.load"), true},
+		{Code("other"), false},
+		{Code(""), false},
+		{fs.ErrNotExist, true},
+		{fs.ErrPermission, false},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(err, %#v) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+	// fmt.Errorf in between does not hide anything
+	if err := fmt.Errorf("startup: %w", err); !errors.Is(err, Code("config.load")) {
+		t.Error("code lost through fmt.Errorf")
+	}
+}
+
+func TestAs(t *testing.T) {
+	err := fmt.Errorf("startup: %w", load())
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatal("errors.As found no *Error")
+	}
+	if e.Msg != "cannot load config" || e.Kind != Internal {
+		t.Errorf("As found %q of kind %v, want the outermost *Error", e.Msg, e.Kind)
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	if err := Wrap(nil, Internal, "never"); err != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", err)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{"nil", nil, Unknown},
+		{"foreign", fs.ErrNotExist, Unknown},
+		{"outermost wins", load(), Internal},
+		{"unknown keeps the cause's kind", Wrap(New(Invalid, "bad"), Unknown, "ctx"), Invalid},
+		{"through fmt.Errorf", fmt.Errorf("x: %w", New(NotFound, "gone")), NotFound},
+	}
+	for _, tt := range tests {
+		if got := KindOf(tt.err); got != tt.want {
+			t.Errorf("%s: KindOf = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"nil", nil, ""},
+		{"none", New(Invalid, "bad"), ""},
+		{"outer", load(), "config.load"},
+		{"inner", Wrap(New(Invalid, "bad", Code("in")), Internal, "ctx"), "in"},
+		{"outer wins", Wrap(New(Invalid, "bad", Code("in")), Internal, "ctx", Code("out")), "out"},
+	}
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.want {
+			t.Errorf("%s: CodeOf = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	err := Wrap(New(NotFound, "missing", "id", 1, "table", "users"), Internal, "lookup", "id", 2)
+	want := map[string]any{"id": 2, "table": "users"}
+	if got := Fields(err); !maps.Equal(got, want) {
+		t.Errorf("Fields = %v, want %v", got, want)
+	}
+	if got := Fields(nil); len(got) != 0 {
+		t.Errorf("Fields(nil) = %v, want empty", got)
+	}
+}
+
+func TestBadKey(t *testing.T) {
+	tests := []struct {
+		name string
+		kv   []any
+		want []field
+	}{
+		{"key not a string", []any{42, "v"}, []field{{"!BADKEY", 42}, {"!BADKEY", "v"}}},
+		{"key without value", []any{"a", 1, "dangling"}, []field{{"a", 1}, {"!BADKEY", "dangling"}}},
+		{"code is not a key", []any{Code("c"), "a", 1}, []field{{"a", 1}}},
+	}
+	for _, tt := range tests {
+		if got := New(Invalid, "bad", tt.kv...).fields; !slices.Equal(got, tt.want) {
+			t.Errorf("%s: fields = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithCopies(t *testing.T) {
+	base := New(Invalid, "bad", "a", 1)
+	more := base.With("b", 2, Code("c"))
+	if len(base.fields) != 1 || base.Code != "" {
+		t.Errorf("With changed the original: fields %v, code %q", base.fields, base.Code)
+	}
+	if got := Fields(more); !maps.Equal(got, map[string]any{"a": 1, "b": 2}) || more.Code != "c" {
+		t.Errorf("With = fields %v, code %q", got, more.Code)
+	}
+	if coded := base.WithCode("x"); base.Code != "" || coded.Code != "x" {
+		t.Errorf("WithCode: original %q, copy %q", base.Code, coded.Code)
+	}
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{New(Invalid, "bad"), "bad"},
+		{load(), "cannot load config: file missing: file does not exist"},
+		{Wrap(fs.ErrNotExist, NotFound, ""), "file does not exist"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestStack(t *testing.T) {
+	e := New(Internal, "here")
+	frames := e.Stack()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "errs.TestStack") {
+		t.Fatalf("Stack()[0] is not the caller of New: %v", frames)
+	}
+	// A literal Error has no stack, rather than one bogus frame
+	if frames := (&Error{Msg: "bare"}).Stack(); frames != nil {
+		t.Errorf("zero-value Stack() = %v, want nil", frames)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	err := load()
+	for _, verb := range []string{"%s", "%v"} {
+		if got := fmt.Sprintf(verb, err); got != err.Error() {
+			t.Errorf("%s = %q, want %q", verb, got, err.Error())
+		}
+	}
+	if got, want := fmt.Sprintf("%q", err), fmt.Sprintf("%q", err.Error()); got != want {
+		t.Errorf("%%q = %s, want %s", got, want)
+	}
+
+	detail := fmt.Sprintf("%+v", err)
+	for _, want := range []string{
+		"cannot load config\n    kind=internal code=config.load attempt=2\n",
+		"\ncaused by: file missing\n    kind=not found path=/etc/app.yaml\n",
+		"\n    basics/recover_advanced/errs.load\n",
+		"errs_test.go:",
+		"\ncaused by: file does not exist",
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("%%+v is missing %q:\n%s", want, detail)
+		}
+	}
+	if strings.Contains(detail, "\n    runtime.") {
+		t.Errorf("%%+v includes runtime frames:\n%s", detail)
+	}
+	if !strings.HasSuffix(detail, "file does not exist") {
+		t.Errorf("%%+v should end with the foreign cause:\n%s", detail)
+	}
+
+	// Without a stack only the message and context are printed
+	if got := fmt.Sprintf("%+v", &Error{Kind: Invalid, Msg: "bare"}); got != "bare\n    kind=invalid" {
+		t.Errorf("%%+v of a literal Error = %q", got)
+	}
+}
@@ -0,0 +1,80 @@
+package errs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format implements fmt.Formatter. %s and %v print the message chain, %q
+// quotes it, and %+v prints every error in the chain on its own lines with
+// its kind, code, context and stack:
+//
+//	user not found
+//	    kind=not found code=user.missing id=42
+//	    main.findUser
+//	        /src/main.go:21
+//	    main.main
+//	        /src/main.go:30
+//	caused by: sql: no rows in result set
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, e.detail())
+			return
+		}
+		io.WriteString(s, e.Error())
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		fmt.Fprintf(s, "%%!%c(*errs.Error=%s)", verb, e.Error())
+	}
+}
+
+// detail renders e and its causes for %+v.
+func (e *Error) detail() string {
+	var b strings.Builder
+	var err error = e
+	for first := true; err != nil; first = false {
+		if !first {
+			b.WriteString("\ncaused by: ")
+		}
+		ee, ok := err.(*Error)
+		if !ok {
+			// A foreign error: print it whole, since its own message
+			// already includes anything it wraps
+			b.WriteString(err.Error())
+			break
+		}
+		b.WriteString(ee.Msg)
+		writeContext(&b, ee)
+		for _, f := range ee.Stack() {
+			if strings.HasPrefix(f.Function, "runtime.") {
+				continue
+			}
+			fmt.Fprintf(&b, "\n    %s\n        %s:%d", f.Function, f.File, f.Line)
+		}
+		err = ee.Err
+	}
+	return b.String()
+}
+
+// writeContext writes e's kind, code and fields on one indented line.
+func writeContext(b *strings.Builder, e *Error) {
+	var parts []string
+	if e.Kind != Unknown {
+		parts = append(parts, "kind="+e.Kind.String())
+	}
+	if e.Code != "" {
+		parts = append(parts, "code="+string(e.Code))
+	}
+	for _, f := range e.fields {
+		parts = append(parts, fmt.Sprintf("%s=%v", f.key, f.value))
+	}
+	if len(parts) > 0 {
+		b.WriteString("\n    " + strings.Join(parts, " "))
+	}
+}
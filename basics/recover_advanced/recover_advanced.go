@@ -2,19 +2,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
 	"runtime/debug"
-)
-
-// CustomError represents an application-specific error
-type CustomError struct {
-	Message string
-}
+	"time"
 
-// Error implements the error interface
-func (e CustomError) Error() string {
-	return e.Message
-}
+	"basics/recover_advanced/crash"
+	"basics/recover_advanced/errs"
+	"basics/recover_advanced/safe"
+	"basics/recover_advanced/stacktrace"
+)
 
 // recoverWithStackTrace demonstrates recover with stack trace printing
 func recoverWithStackTrace() {
@@ -22,7 +26,14 @@ func recoverWithStackTrace() {
 		if r := recover(); r != nil {
 			fmt.Println("Panic occurred:", r)
 			fmt.Println("\nStack trace:")
-			fmt.Println(string(debug.Stack()))
+			stack := debug.Stack()
+			fmt.Println(string(stack))
+
+			// Parsed, the same stack can drop the runtime's own frames
+			if gs, err := stacktrace.Parse(stack); err == nil {
+				fmt.Println("Program frames only:")
+				fmt.Print(stacktrace.Render(stacktrace.Group(gs), stacktrace.Options{}))
+			}
 		}
 	}()
 
@@ -45,9 +56,10 @@ func recoverAndRethrow() {
 	panic("critical error")
 }
 
-// panicWithCustomError demonstrates panicking with a custom error type
+// panicWithCustomError demonstrates panicking with a custom error type:
+// an application error built with the errs package
 func panicWithCustomError() {
-	panic(CustomError{Message: "custom error occurred"})
+	panic(errs.New(errs.Internal, "custom error occurred", errs.Code("app.custom")))
 }
 
 // recoverCustomError demonstrates type checking in recover
@@ -55,8 +67,8 @@ func recoverCustomError() {
 	defer func() {
 		if r := recover(); r != nil {
 			// Type assertion to check if it's our custom error
-			if customErr, ok := r.(CustomError); ok {
-				fmt.Println("Recovered from custom error:", customErr.Message)
+			if customErr, ok := r.(*errs.Error); ok {
+				fmt.Println("Recovered from custom error:", customErr.Msg, "- code:", customErr.Code)
 			} else if err, ok := r.(error); ok {
 				fmt.Println("Recovered from standard error:", err.Error())
 			} else {
@@ -98,25 +110,22 @@ func recoverFromGoroutine() {
 		}
 	}()
 
-	// Create a goroutine that will panic
-	go func() {
-		// Each goroutine needs its own recover
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Println("Recovered from panic in goroutine:", r)
-			}
-		}()
-		
+	// Each goroutine needs its own recover. A Supervisor installs one in
+	// every goroutine it starts, and here restarts a crashed worker once
+	sup := safe.NewSupervisor(context.Background(), safe.Times(1))
+	sup.Go("panicker", func(ctx context.Context) error {
 		panic("panic in goroutine")
-	}()
+	})
 
-	// Give the goroutine time to execute
+	// Wait for the goroutine properly instead of guessing how long it takes
 	fmt.Println("Waiting for goroutine...")
-	// In a real program, you would use proper synchronization
-	// This is just for demonstration
-	for i := 0; i < 1000000; i++ {
-		// Busy wait
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sup.Wait(ctx); err != nil {
+		fmt.Println("Recovered from panic in goroutine:", err)
 	}
+	stats := sup.Stats()
+	fmt.Printf("panics: %d, restarts: %d\n", stats.Panics, stats.Restarts)
 }
 
 // recoverWithErrorHandling shows how to convert panics to errors
@@ -132,6 +141,163 @@ func recoverWithErrorHandling() (err error) {
 	panic("something failed")
 }
 
+// safeExamples shows the reusable versions of the patterns above
+func safeExamples() {
+	// safe.Do turns a panic into a *safe.PanicError carrying the value and stack
+	err := safe.Do(func() error {
+		panicWithCustomError()
+		return nil
+	})
+	var pe *safe.PanicError
+	if errors.As(err, &pe) {
+		fmt.Println("safe.Do returned:", err, "- stack captured:", len(pe.Stack) > 0)
+	}
+	// PanicError unwraps to the panic value when it is an error
+	var custom *errs.Error
+	if errors.As(err, &custom) {
+		fmt.Println("the panic value was an *errs.Error:", custom.Msg)
+	}
+
+	// safe.Go reports each goroutine's result on a channel
+	errs := make(chan error)
+	safe.Go(func() error { panic("panic in goroutine") }, errs)
+	safe.Go(func() error { return nil }, errs)
+	for range 2 {
+		fmt.Println("goroutine finished with:", <-errs)
+	}
+
+	// safe.Retry goes further than recoverWithErrorHandling: it turns each
+	// panic into an error and tries again, backing off between attempts
+	calls := 0
+	n, err := safe.Retry(context.Background(), safe.RetryPolicy{
+		BaseDelay: time.Millisecond,
+		OnAttempt: func(a safe.Attempt) { fmt.Printf("attempt %d: %v\n", a.Number, a.Err) },
+	}, func(ctx context.Context) (int, error) {
+		if calls++; calls < 3 {
+			panic("flaky dependency")
+		}
+		return 42, nil
+	})
+	fmt.Println("safe.Retry returned:", n, err)
+
+	// safe.Handler answers a panicking request with a 500 and logs the
+	// stack; the log is discarded here to keep the output short
+	h := safe.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler exploded")
+	}), log.New(io.Discard, "", 0))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/boom", nil))
+	fmt.Println("HTTP status:", rec.Code)
+}
+
+// loadConfig fails with a structured error wrapping a lower-level one
+func loadConfig(name string) error {
+	cause := errs.New(errs.NotFound, "file missing", "path", "/etc/"+name)
+	return errs.Wrap(cause, errs.Internal, "cannot load config", errs.Code("config.load"))
+}
+
+// errsExamples shows what else the errs package carries besides a message
+func errsExamples() {
+	err := loadConfig("app.yaml")
+	fmt.Println("error:", err)
+	fmt.Println("is NotFound:", errors.Is(err, errs.NotFound), "- code:", errs.CodeOf(err), "- context:", errs.Fields(err))
+
+	// A structured error survives being thrown as a panic and recovered
+	err = safe.Do(func() error { panic(err) })
+	fmt.Println("still has code after a panic:", errors.Is(err, errs.Code("config.load")))
+
+	// %+v prints every layer with its kind, context and stack
+	fmt.Printf("%+v\n", loadConfig("db.yaml"))
+}
+
+// crashReportExample saves the panic to a JSON file instead of printing the
+// stack like recoverWithStackTrace does. The reports go to a temporary
+// directory that is removed afterwards, so running the lesson leaves
+// nothing behind; a real program would use crash.DefaultDir or its own.
+func crashReportExample() {
+	dir, err := os.MkdirTemp("", "crash-reports-")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	reporter, err := crash.NewReporter(dir, 10)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	func() {
+		defer reporter.Recover()
+		panicWithCustomError()
+	}()
+
+	paths, _ := crash.List(reporter.Dir)
+	fmt.Printf("%d report(s) written\n", len(paths))
+	for _, p := range paths {
+		if rep, err := crash.Load(p); err == nil {
+			fmt.Println(rep.Summary())
+		}
+	}
+	fmt.Println("view saved reports with: go run ./recover_advanced/crashreport -dir DIR list")
+}
+
+// groupStacksExample dumps every goroutine and groups identical stacks, the
+// way you would look for leaked or stuck goroutines
+func groupStacksExample() {
+	block := make(chan struct{})
+	defer close(block)
+	for range 3 {
+		go func() {
+			<-block
+		}()
+	}
+
+	gs, err := waitForBlocked(3, "chan receive", time.Second)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	buckets := stacktrace.Group(gs)
+	fmt.Print(stacktrace.Render(buckets[:1], stacktrace.Options{}))
+}
+
+// waitForBlocked returns a dump of every goroutine once n of them share a
+// stack in the given state. The runtime cannot signal when a goroutine has
+// parked, so the dump is retaken every millisecond until the deadline.
+func waitForBlocked(n int, state string, timeout time.Duration) ([]*stacktrace.Goroutine, error) {
+	tick := time.NewTicker(time.Millisecond)
+	defer tick.Stop()
+	deadline := time.After(timeout)
+	for {
+		gs, err := stacktrace.Parse(allStacks())
+		if err != nil {
+			return nil, err
+		}
+		if b := stacktrace.Group(gs); len(b) > 0 && len(b[0].IDs) >= n && b[0].State == state {
+			return gs, nil
+		}
+		select {
+		case <-tick.C:
+		case <-deadline:
+			return nil, fmt.Errorf("no %d goroutines in %q after %v", n, state, timeout)
+		}
+	}
+}
+
+// allStacks returns the stacks of all goroutines, growing the buffer until
+// runtime.Stack no longer fills it.
+func allStacks() []byte {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
 func main() {
 	fmt.Println("=== Advanced Recover Examples ===")
 
@@ -152,7 +318,19 @@ func main() {
 	fmt.Println("\n5. Nested panic and recover:")
 	nestedPanic()
 
-	fmt.Println("\n6. Recover and rethrow (this will terminate the program):")
+	fmt.Println("\n6. The safe package:")
+	safeExamples()
+
+	fmt.Println("\n7. Structured errors:")
+	errsExamples()
+
+	fmt.Println("\n8. Crash reports:")
+	crashReportExample()
+
+	fmt.Println("\n9. Grouping goroutine stacks:")
+	groupStacksExample()
+
+	fmt.Println("\n10. Recover and rethrow (this will terminate the program):")
 	// This is commented out because it would terminate the program
 	// Uncomment to see the effect
 	// recoverAndRethrow()
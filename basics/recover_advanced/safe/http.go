@@ -0,0 +1,113 @@
+package safe
+
+import (
+	"bufio"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+)
+
+// statusWriter remembers whether the response header has been sent, since
+// after that a 500 can no longer be reported to the client.
+type statusWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Handlers often check for http.Flusher and http.Hijacker with a type
+// assertion rather than going through http.ResponseController, so the
+// wrapper has them exactly when the underlying writer does.
+type (
+	flushWriter  struct{ *statusWriter }
+	hijackWriter struct{ *statusWriter }
+
+	flushHijackWriter struct {
+		*statusWriter
+		http.Flusher
+		http.Hijacker
+	}
+)
+
+func (w flushWriter) Flush() { w.flush() }
+
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) { return w.hijack() }
+
+// flush sends the header, if it has not been, and any buffered body.
+func (w *statusWriter) flush() {
+	w.wroteHeader = true
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijack hands the connection to the caller, after which nothing more can
+// be written through w.
+func (w *statusWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := w.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// wrap returns a statusWriter around w, and the http.ResponseWriter to pass
+// on, which implements the same optional interfaces as w.
+func wrap(w http.ResponseWriter) (*statusWriter, http.ResponseWriter) {
+	sw := &statusWriter{ResponseWriter: w}
+	_, canFlush := w.(http.Flusher)
+	_, canHijack := w.(http.Hijacker)
+	switch {
+	case canFlush && canHijack:
+		return sw, flushHijackWriter{sw, flushWriter{sw}, hijackWriter{sw}}
+	case canFlush:
+		return sw, flushWriter{sw}
+	case canHijack:
+		return sw, hijackWriter{sw}
+	}
+	return sw, sw
+}
+
+// Handler wraps next so that a panic while serving a request is logged to
+// logger, with its stack, and answered with 500 Internal Server Error. If
+// next had already started the response, the status cannot change and the
+// panic is only logged. A nil logger means log.Default().
+//
+// Panics with http.ErrAbortHandler are passed on, as net/http uses them to
+// abort a response silently.
+func Handler(next http.Handler, logger *log.Logger) http.Handler {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw, wrapped := wrap(w)
+		defer func() {
+			err := recovered(recover())
+			if err == nil {
+				return
+			}
+			pe := err.(*PanicError)
+			if errors.Is(pe, http.ErrAbortHandler) {
+				panic(pe.Value)
+			}
+			logger.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, pe.Value, pe.Stack)
+			if !sw.wroteHeader {
+				http.Error(sw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(wrapped, r)
+	})
+}
@@ -0,0 +1,101 @@
+package safe
+
+import (
+	"bufio"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// plainWriter is a ResponseWriter with none of the optional interfaces.
+type plainWriter struct{ rec *httptest.ResponseRecorder }
+
+func (w plainWriter) Header() http.Header         { return w.rec.Header() }
+func (w plainWriter) Write(b []byte) (int, error) { return w.rec.Write(b) }
+func (w plainWriter) WriteHeader(code int)        { w.rec.WriteHeader(code) }
+
+// hijackRecorder adds http.Hijacker to a ResponseRecorder, which already
+// implements http.Flusher.
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (w *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	a, b := net.Pipe()
+	b.Close()
+	return a, bufio.NewReadWriter(bufio.NewReader(a), bufio.NewWriter(a)), nil
+}
+
+func quietLogger() *log.Logger { return log.New(io.Discard, "", 0) }
+
+func TestHandlerForwardsInterfaces(t *testing.T) {
+	tests := []struct {
+		name              string
+		w                 http.ResponseWriter
+		flusher, hijacker bool
+	}{
+		{"plain", plainWriter{httptest.NewRecorder()}, false, false},
+		{"flusher", httptest.NewRecorder(), true, false},
+		{"both", &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}, true, true},
+	}
+	for _, tt := range tests {
+		h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, f := w.(http.Flusher)
+			_, h := w.(http.Hijacker)
+			if f != tt.flusher || h != tt.hijacker {
+				t.Errorf("%s: Flusher %t, Hijacker %t; want %t, %t", tt.name, f, h, tt.flusher, tt.hijacker)
+			}
+		}), quietLogger())
+		h.ServeHTTP(tt.w, httptest.NewRequest("GET", "/", nil))
+	}
+}
+
+func TestHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    int
+	}{
+		{"before writing", func(w http.ResponseWriter, r *http.Request) { panic("boom") }, http.StatusInternalServerError},
+		{"after WriteHeader", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			panic("boom")
+		}, http.StatusAccepted},
+		{"after Flush", func(w http.ResponseWriter, r *http.Request) {
+			w.(http.Flusher).Flush()
+			panic("boom")
+		}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		Handler(tt.handler, quietLogger()).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+
+	// Once the connection is hijacked, nothing more is written to it
+	rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+	Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+		panic("boom")
+	}), quietLogger()).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if !rec.hijacked || rec.Body.Len() != 0 {
+		t.Errorf("after Hijack: hijacked %t, body %q", rec.hijacked, rec.Body)
+	}
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("ErrAbortHandler: recovered %v, want it passed on", r)
+		}
+	}()
+	Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), quietLogger()).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
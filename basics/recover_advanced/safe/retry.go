@@ -0,0 +1,154 @@
+package safe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Retryable is implemented by errors that know whether trying again could
+// help. Retry looks for it anywhere in an error's chain.
+type Retryable interface {
+	Retryable() bool
+}
+
+// permanent marks an error as not worth retrying.
+type permanent struct{ err error }
+
+func (p permanent) Error() string   { return p.err.Error() }
+func (p permanent) Unwrap() error   { return p.err }
+func (p permanent) Retryable() bool { return false }
+
+// Permanent wraps err so that Retry returns it at once instead of trying
+// again. It returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanent{err}
+}
+
+// IsRetryable reports whether Retry would try again after err. Context
+// errors never are, errors implementing Retryable decide for themselves,
+// and anything else, including a recovered panic, is.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var r Retryable
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+	return true
+}
+
+// Clock waits between attempts. Retry uses the real clock unless a
+// RetryPolicy says otherwise, which lets tests run without sleeping.
+//
+// A context's deadline is always in real time, so Retry checks waits
+// against it with the real clock, whatever the Clock.
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Attempt describes one finished call made by Retry.
+type Attempt struct {
+	Number int           // counting from 1
+	Err    error         // nil if the call succeeded
+	Delay  time.Duration // wait before the next attempt; 0 if there is none
+}
+
+// RetryPolicy configures Retry. Zero fields take the defaults noted.
+type RetryPolicy struct {
+	MaxAttempts int           // total calls, including the first; default 3
+	BaseDelay   time.Duration // wait after the first failure; default 100ms
+	MaxDelay    time.Duration // upper bound on any wait; default 10s
+	// Jitter randomly shortens each wait by up to this fraction, so many
+	// clients retrying at once spread out. 0 means no jitter; at most 1.
+	Jitter float64
+	// Rand returns a number in [0, 1) for jitter; default rand.Float64.
+	Rand func() float64
+	// Clock defaults to the real clock.
+	Clock Clock
+	// OnAttempt, if set, is called after every call.
+	OnAttempt func(Attempt)
+}
+
+// delay returns the wait after the given failed attempt: BaseDelay doubled
+// for each earlier failure, capped at MaxDelay, less the jitter.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	d := p.MaxDelay
+	if shift := attempt - 1; shift < 63 && p.BaseDelay <= p.MaxDelay>>shift {
+		d = p.BaseDelay << shift
+	}
+	if j := min(p.Jitter, 1); j > 0 {
+		d -= time.Duration(float64(d) * j * p.Rand())
+	}
+	return d
+}
+
+// Retry calls f until it succeeds, returns an error that is not retryable
+// (see IsRetryable), or has been called MaxAttempts times. A panic in f is
+// recovered and treated as a retryable *PanicError.
+//
+// Between attempts Retry waits with exponential backoff. It stops early
+// when ctx is done, and does not start a wait that would outlast ctx's
+// deadline. The error returned is the last one f returned, wrapped with
+// the reason Retry stopped.
+func Retry[T any](ctx context.Context, p RetryPolicy, f func(ctx context.Context) (T, error)) (T, error) {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = 100 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 10 * time.Second
+	}
+	if p.Rand == nil {
+		p.Rand = rand.Float64
+	}
+	if p.Clock == nil {
+		p.Clock = realClock{}
+	}
+
+	var zero T
+	for n := 1; ; n++ {
+		var v T
+		err := Do(func() (err error) {
+			v, err = f(ctx)
+			return err
+		})
+
+		var wait time.Duration
+		if err != nil && IsRetryable(err) && n < p.MaxAttempts {
+			wait = p.delay(n)
+		}
+		if p.OnAttempt != nil {
+			p.OnAttempt(Attempt{Number: n, Err: err, Delay: wait})
+		}
+
+		switch {
+		case err == nil:
+			return v, nil
+		case !IsRetryable(err):
+			return zero, err
+		case n == p.MaxAttempts:
+			return zero, fmt.Errorf("safe: giving up after %d attempts: %w", n, err)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return zero, fmt.Errorf("safe: next attempt would pass the deadline: %w", errors.Join(err, context.DeadlineExceeded))
+		}
+		select {
+		case <-ctx.Done():
+			return zero, fmt.Errorf("safe: stopped retrying: %w", errors.Join(err, ctx.Err()))
+		case <-p.Clock.After(wait):
+		}
+	}
+}
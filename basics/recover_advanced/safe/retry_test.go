@@ -0,0 +1,249 @@
+package safe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock fires at once and records every wait.
+type fakeClock struct{ waits []time.Duration }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+// gateClock reports each wait on waits and never fires.
+type gateClock struct{ waits chan time.Duration }
+
+func (c gateClock) After(d time.Duration) <-chan time.Time {
+	c.waits <- d
+	return nil
+}
+
+var errFlaky = errors.New("flaky")
+
+// failing returns f that fails n times and then returns the number of
+// calls; n < 0 fails forever. calls counts every call.
+func failing(n int, calls *int) func(context.Context) (int, error) {
+	return func(context.Context) (int, error) {
+		*calls++
+		if n < 0 || *calls <= n {
+			return 0, errFlaky
+		}
+		return *calls, nil
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   []time.Duration
+	}{
+		{"defaults", RetryPolicy{}, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}},
+		{"doubling up to the cap",
+			RetryPolicy{MaxAttempts: 6, BaseDelay: time.Second, MaxDelay: 5 * time.Second},
+			[]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{"base above the cap",
+			RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Second},
+			[]time.Duration{time.Second, time.Second}},
+		{"half jitter at the midpoint",
+			RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, Jitter: 0.5, Rand: func() float64 { return 0.5 }},
+			[]time.Duration{750 * time.Millisecond, 1500 * time.Millisecond}},
+		{"jitter with a zero draw",
+			RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, Jitter: 0.5, Rand: func() float64 { return 0 }},
+			[]time.Duration{time.Second, 2 * time.Second}},
+		{"jitter above 1 is capped",
+			RetryPolicy{MaxAttempts: 2, BaseDelay: time.Second, Jitter: 3, Rand: func() float64 { return 0.5 }},
+			[]time.Duration{500 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		var clock fakeClock
+		tt.policy.Clock = &clock
+		calls := 0
+		_, err := Retry(context.Background(), tt.policy, failing(-1, &calls))
+		if !errors.Is(err, errFlaky) || !strings.Contains(err.Error(), fmt.Sprintf("giving up after %d attempts", calls)) {
+			t.Errorf("%s: Retry error = %v", tt.name, err)
+		}
+		if calls != len(tt.want)+1 {
+			t.Errorf("%s: %d calls, want %d", tt.name, calls, len(tt.want)+1)
+		}
+		if !slices.Equal(clock.waits, tt.want) {
+			t.Errorf("%s: waited %v, want %v", tt.name, clock.waits, tt.want)
+		}
+	}
+}
+
+func TestRetryDelayDoesNotOverflow(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Hour, MaxDelay: 100 * time.Hour}
+	for _, attempt := range []int{8, 62, 63, 64, 1000} {
+		if got := p.delay(attempt); got != p.MaxDelay {
+			t.Errorf("delay(%d) = %v, want %v", attempt, got, p.MaxDelay)
+		}
+	}
+}
+
+func TestRetrySucceeds(t *testing.T) {
+	var clock fakeClock
+	calls := 0
+	v, err := Retry(context.Background(), RetryPolicy{MaxAttempts: 5, Clock: &clock}, failing(2, &calls))
+	if err != nil || v != 3 {
+		t.Errorf("Retry = %d, %v; want 3, nil", v, err)
+	}
+	if len(clock.waits) != 2 {
+		t.Errorf("waited %d times, want 2", len(clock.waits))
+	}
+}
+
+// verdict is an error that decides for itself whether to retry.
+type verdict bool
+
+func (v verdict) Error() string   { return fmt.Sprintf("verdict %t", bool(v)) }
+func (v verdict) Retryable() bool { return bool(v) }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errFlaky, true},
+		{Permanent(errFlaky), false},
+		{fmt.Errorf("wrapped: %w", Permanent(errFlaky)), false},
+		{verdict(true), true},
+		{verdict(false), false},
+		{fmt.Errorf("wrapped: %w", verdict(false)), false},
+		{context.Canceled, false},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), false},
+		{&PanicError{Value: "boom"}, true},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %t, want %t", tt.err, got, tt.want)
+		}
+	}
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+}
+
+func TestRetryStopsOnPermanent(t *testing.T) {
+	for _, fail := range []error{Permanent(errFlaky), verdict(false), context.Canceled} {
+		var clock fakeClock
+		calls := 0
+		_, err := Retry(context.Background(), RetryPolicy{Clock: &clock}, func(context.Context) (int, error) {
+			calls++
+			return 0, fail
+		})
+		if calls != 1 || len(clock.waits) != 0 {
+			t.Errorf("%v: %d calls, %d waits; want 1 call, no waits", fail, calls, len(clock.waits))
+		}
+		// The error comes back as it is, not wrapped in "giving up"
+		if err != fail {
+			t.Errorf("%v: Retry error = %v", fail, err)
+		}
+	}
+}
+
+func TestRetryRecoversPanics(t *testing.T) {
+	var (
+		clock    fakeClock
+		attempts []Attempt
+	)
+	calls := 0
+	v, err := Retry(context.Background(), RetryPolicy{
+		Clock:     &clock,
+		BaseDelay: time.Second,
+		OnAttempt: func(a Attempt) { attempts = append(attempts, a) },
+	}, func(context.Context) (string, error) {
+		if calls++; calls == 1 {
+			panic("boom")
+		}
+		return "ok", nil
+	})
+	if err != nil || v != "ok" {
+		t.Fatalf("Retry = %q, %v", v, err)
+	}
+	var pe *PanicError
+	if len(attempts) != 2 || !errors.As(attempts[0].Err, &pe) || pe.Value != "boom" {
+		t.Fatalf("attempts = %+v, want a recovered panic first", attempts)
+	}
+	if attempts[0].Delay != time.Second || attempts[1].Err != nil || attempts[1].Delay != 0 {
+		t.Errorf("attempts = %+v", attempts)
+	}
+}
+
+func TestRetryOnAttempt(t *testing.T) {
+	var (
+		clock    fakeClock
+		attempts []Attempt
+	)
+	calls := 0
+	Retry(context.Background(), RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   time.Second,
+		Clock:       &clock,
+		OnAttempt:   func(a Attempt) { attempts = append(attempts, a) },
+	}, failing(-1, &calls))
+
+	want := []Attempt{
+		{1, errFlaky, time.Second},
+		{2, errFlaky, 2 * time.Second},
+		{3, errFlaky, 4 * time.Second},
+		{4, errFlaky, 0}, // the last attempt has no wait after it
+	}
+	if !slices.Equal(attempts, want) {
+		t.Errorf("attempts = %+v, want %+v", attempts, want)
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	clock := gateClock{make(chan time.Duration)}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	calls := 0
+	go func() {
+		_, err := Retry(ctx, RetryPolicy{MaxAttempts: 10, Clock: clock}, failing(-1, &calls))
+		done <- err
+	}()
+
+	<-clock.waits // Retry is now waiting after the first failure
+	cancel()
+	err := <-done
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errFlaky) {
+		t.Errorf("Retry error = %v, want the last failure and context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Errorf("%d calls after cancelling, want 1", calls)
+	}
+}
+
+func TestRetryDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	// A wait that would end after the deadline is not started at all
+	var clock fakeClock
+	calls := 0
+	_, err := Retry(ctx, RetryPolicy{BaseDelay: 2 * time.Hour, MaxDelay: 2 * time.Hour, Clock: &clock}, failing(-1, &calls))
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errFlaky) {
+		t.Errorf("Retry error = %v, want the last failure and DeadlineExceeded", err)
+	}
+	if calls != 1 || len(clock.waits) != 0 {
+		t.Errorf("%d calls, %d waits; want 1 call, no waits", calls, len(clock.waits))
+	}
+
+	// The deadline is measured in real time, so a fake clock that never
+	// advances does not hide it, and short waits still go ahead
+	clock, calls = fakeClock{}, 0
+	if _, err := Retry(ctx, RetryPolicy{BaseDelay: time.Second, Clock: &clock}, failing(2, &calls)); err != nil {
+		t.Errorf("Retry with waits well inside the deadline = %v", err)
+	}
+}
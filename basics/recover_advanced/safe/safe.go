@@ -0,0 +1,64 @@
+// Package safe turns panics into ordinary errors, so one bad call, goroutine
+// or HTTP request cannot take down the whole program.
+package safe
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a recovered panic. Value is whatever was passed to panic and
+// Stack is the stack of the panicking goroutine, captured as it unwound.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns Value if it is an error, so errors.Is and errors.As can
+// see through a panic(err) to err.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// recovered converts a value returned by recover into a *PanicError, or
+// nil if there was no panic.
+func recovered(r any) error {
+	if r == nil {
+		return nil
+	}
+	return &PanicError{Value: r, Stack: debug.Stack()}
+}
+
+// Do calls f and returns its error, or a *PanicError if f panics.
+func Do(f func() error) (err error) {
+	defer func() {
+		if pe := recovered(recover()); pe != nil {
+			err = pe
+		}
+	}()
+	return f()
+}
+
+// Go runs f in a new goroutine and sends exactly one value to errs when it
+// finishes: f's error, a *PanicError, or nil on success. Because a value is
+// always sent, the receiver can wait for n goroutines by receiving n times.
+func Go(f func() error, errs chan<- error) {
+	go func() {
+		errs <- Do(f)
+	}()
+}
+
+// GoFunc runs f in a new goroutine and calls handle, from that goroutine,
+// if f returns an error or panics. A panic inside handle is not recovered.
+func GoFunc(f func() error, handle func(error)) {
+	go func() {
+		if err := Do(f); err != nil {
+			handle(err)
+		}
+	}()
+}
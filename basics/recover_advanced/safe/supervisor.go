@@ -0,0 +1,176 @@
+package safe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// Restart says how many times a Supervisor restarts a worker that panics.
+type Restart int
+
+const (
+	// Never leaves a worker stopped after its first panic.
+	Never Restart = 0
+	// Always restarts a worker after every panic.
+	Always Restart = -1
+)
+
+// Times restarts a worker up to n times, after which its last panic is
+// reported by Wait. Times(0) is the same as Never.
+func Times(n int) Restart {
+	return Restart(max(n, 0))
+}
+
+func (r Restart) allows(restarts int) bool {
+	return r == Always || restarts < int(r)
+}
+
+// Stats counts what a Supervisor's workers have done so far.
+type Stats struct {
+	Started  int            // workers passed to Go
+	Panics   int            // panics recovered, across all workers and restarts
+	Restarts int            // restarts performed
+	GaveUp   int            // workers whose last run panicked and were not restarted
+	ByWorker map[string]int // panics per worker name
+	Last     *PanicError    // most recent panic, or nil
+}
+
+// Backoff returns how long to wait before the given restart, counting from
+// 1. The default doubles from 10ms up to a limit of 1s.
+type Backoff func(restart int) time.Duration
+
+// DefaultBackoff is the Backoff used by NewSupervisor.
+func DefaultBackoff(restart int) time.Duration {
+	d := 10 * time.Millisecond << min(restart-1, 7)
+	return min(d, time.Second)
+}
+
+// Supervisor runs a group of worker goroutines, recovering their panics and
+// restarting them according to its Restart policy. A worker that returns,
+// with or without an error, is finished and is not restarted.
+//
+// Set the exported fields before the first call to Go.
+type Supervisor struct {
+	Policy  Restart
+	Backoff Backoff
+	// After waits before a restart, like time.After. Replacing it lets
+	// tests restart workers instantly and without sleeping.
+	After func(time.Duration) <-chan time.Time
+	// OnPanic, if set, is called from the worker's goroutine after each
+	// recovered panic.
+	OnPanic func(name string, err *PanicError)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex
+	stats Stats
+	errs  []error
+}
+
+// NewSupervisor returns a Supervisor whose workers run with a context
+// derived from ctx, which is cancelled by Stop.
+func NewSupervisor(ctx context.Context, policy Restart) *Supervisor {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Supervisor{
+		Policy:  policy,
+		Backoff: DefaultBackoff,
+		After:   time.After,
+		ctx:     ctx,
+		cancel:  cancel,
+		stats:   Stats{ByWorker: make(map[string]int)},
+	}
+}
+
+// Go starts f in a new goroutine under the supervisor. name identifies the
+// worker in errors and statistics.
+func (s *Supervisor) Go(name string, f func(ctx context.Context) error) {
+	s.mu.Lock()
+	s.stats.Started++
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.run(name, f); err != nil {
+			s.mu.Lock()
+			s.errs = append(s.errs, fmt.Errorf("worker %s: %w", name, err))
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// run calls f until it returns, or panics and may not be restarted.
+func (s *Supervisor) run(name string, f func(ctx context.Context) error) error {
+	for restarts := 0; ; restarts++ {
+		err := Do(func() error { return f(s.ctx) })
+		pe, ok := err.(*PanicError)
+		if !ok {
+			return err
+		}
+
+		s.mu.Lock()
+		s.stats.Panics++
+		s.stats.ByWorker[name]++
+		s.stats.Last = pe
+		giveUp := !s.Policy.allows(restarts)
+		if giveUp {
+			s.stats.GaveUp++
+		}
+		s.mu.Unlock()
+		if s.OnPanic != nil {
+			s.OnPanic(name, pe)
+		}
+		if giveUp {
+			return pe
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return errors.Join(pe, s.ctx.Err())
+		case <-s.After(s.Backoff(restarts + 1)):
+		}
+		s.mu.Lock()
+		s.stats.Restarts++
+		s.mu.Unlock()
+	}
+}
+
+// Wait blocks until every worker has finished, or ctx is done. It returns
+// the errors of the workers that failed, joined with errors.Join, or ctx's
+// error if it gave up waiting.
+func (s *Supervisor) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.errs...)
+}
+
+// Stop cancels the context passed to the workers and stops any pending
+// restarts. Workers that ignore their context keep running.
+func (s *Supervisor) Stop() {
+	s.cancel()
+}
+
+// Stats returns a snapshot of the supervisor's statistics.
+func (s *Supervisor) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.stats
+	st.ByWorker = maps.Clone(s.stats.ByWorker)
+	return st
+}
@@ -0,0 +1,254 @@
+package safe
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// instant is an After that fires at once and records what it was asked to
+// wait, so restarts happen without sleeping.
+type instant struct {
+	mu    sync.Mutex
+	waits []time.Duration
+}
+
+func (c *instant) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.waits = append(c.waits, d)
+	c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+// gate is an After that reports each wait on waits and fires only when the
+// test sends on fire.
+type gate struct {
+	waits chan time.Duration
+	fire  chan time.Time
+}
+
+func newGate() *gate {
+	return &gate{waits: make(chan time.Duration), fire: make(chan time.Time)}
+}
+
+func (g *gate) After(d time.Duration) <-chan time.Time {
+	g.waits <- d
+	return g.fire
+}
+
+// panicky returns a worker that panics the first n times it runs and then
+// returns nil; n < 0 panics forever.
+func panicky(n int) func(context.Context) error {
+	var mu sync.Mutex
+	runs := 0
+	return func(context.Context) error {
+		mu.Lock()
+		runs++
+		r := runs
+		mu.Unlock()
+		if n < 0 || r <= n {
+			panic("boom")
+		}
+		return nil
+	}
+}
+
+func newTestSupervisor(policy Restart, after func(time.Duration) <-chan time.Time) *Supervisor {
+	s := NewSupervisor(context.Background(), policy)
+	s.Backoff = func(restart int) time.Duration { return time.Duration(restart) * time.Second }
+	s.After = after
+	return s
+}
+
+func TestSupervisorPolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    Restart
+		panics    int // how often the worker panics before succeeding; -1 forever
+		wantErr   bool
+		wantStats Stats
+	}{
+		{"never", Never, -1, true, Stats{Started: 1, Panics: 1, GaveUp: 1}},
+		{"times(0)", Times(0), -1, true, Stats{Started: 1, Panics: 1, GaveUp: 1}},
+		{"negative times", Times(-3), -1, true, Stats{Started: 1, Panics: 1, GaveUp: 1}},
+		{"times(3), gives up", Times(3), -1, true, Stats{Started: 1, Panics: 4, Restarts: 3, GaveUp: 1}},
+		{"times(3), recovers", Times(3), 3, false, Stats{Started: 1, Panics: 3, Restarts: 3}},
+		{"always", Always, 25, false, Stats{Started: 1, Panics: 25, Restarts: 25}},
+		{"no panic", Never, 0, false, Stats{Started: 1}},
+	}
+	for _, tt := range tests {
+		var clock instant
+		s := newTestSupervisor(tt.policy, clock.After)
+		s.Go("w", panicky(tt.panics))
+		err := s.Wait(context.Background())
+
+		var pe *PanicError
+		if got := errors.As(err, &pe); got != tt.wantErr {
+			t.Errorf("%s: Wait = %v, want a PanicError: %t", tt.name, err, tt.wantErr)
+		}
+		if pe != nil && pe.Value != "boom" {
+			t.Errorf("%s: panic value %v", tt.name, pe.Value)
+		}
+		st := s.Stats()
+		if st.Started != tt.wantStats.Started || st.Panics != tt.wantStats.Panics ||
+			st.Restarts != tt.wantStats.Restarts || st.GaveUp != tt.wantStats.GaveUp {
+			t.Errorf("%s: Stats = %+v, want %+v", tt.name, st, tt.wantStats)
+		}
+		if st.ByWorker["w"] != tt.wantStats.Panics {
+			t.Errorf("%s: ByWorker = %v, want %d panics", tt.name, st.ByWorker, tt.wantStats.Panics)
+		}
+		if (st.Last != nil) != (tt.wantStats.Panics > 0) {
+			t.Errorf("%s: Last = %v", tt.name, st.Last)
+		}
+
+		// Every restart waited for the backoff of its own number
+		want := make([]time.Duration, tt.wantStats.Restarts)
+		for i := range want {
+			want[i] = time.Duration(i+1) * time.Second
+		}
+		if !slices.Equal(clock.waits, want) {
+			t.Errorf("%s: waited %v, want %v", tt.name, clock.waits, want)
+		}
+	}
+}
+
+func TestSupervisorReturnedErrorIsNotRestarted(t *testing.T) {
+	s := newTestSupervisor(Always, func(time.Duration) <-chan time.Time {
+		t.Error("a worker that returned an error was restarted")
+		return nil
+	})
+	failure := errors.New("failed")
+	s.Go("w", func(context.Context) error { return failure })
+	if err := s.Wait(context.Background()); !errors.Is(err, failure) {
+		t.Errorf("Wait = %v, want %v", err, failure)
+	}
+	if st := s.Stats(); st.Panics != 0 || st.Restarts != 0 {
+		t.Errorf("Stats = %+v", st)
+	}
+}
+
+func TestSupervisorStopDuringBackoff(t *testing.T) {
+	g := newGate()
+	s := newTestSupervisor(Always, g.After)
+	s.Go("w", panicky(-1))
+
+	// The worker has panicked and is now waiting out its first backoff
+	if d := <-g.waits; d != time.Second {
+		t.Errorf("first backoff %v, want 1s", d)
+	}
+	s.Stop()
+
+	err := s.Wait(context.Background())
+	var pe *PanicError
+	if !errors.Is(err, context.Canceled) || !errors.As(err, &pe) {
+		t.Errorf("Wait = %v, want the panic and context.Canceled", err)
+	}
+	if st := s.Stats(); st.Panics != 1 || st.Restarts != 0 || st.GaveUp != 0 {
+		t.Errorf("Stats = %+v", st)
+	}
+}
+
+func TestSupervisorGatedRestarts(t *testing.T) {
+	g := newGate()
+	s := newTestSupervisor(Times(2), g.After)
+	s.Go("w", panicky(-1))
+
+	for want := 1; want <= 2; want++ {
+		if d := <-g.waits; d != time.Duration(want)*time.Second {
+			t.Errorf("backoff %d = %v", want, d)
+		}
+		// Nothing restarts until the timer fires
+		if st := s.Stats(); st.Restarts != want-1 {
+			t.Errorf("before firing, Restarts = %d, want %d", st.Restarts, want-1)
+		}
+		g.fire <- time.Time{}
+	}
+	if err := s.Wait(context.Background()); err == nil {
+		t.Error("Wait = nil after the worker gave up")
+	}
+	if st := s.Stats(); st.Panics != 3 || st.Restarts != 2 || st.GaveUp != 1 {
+		t.Errorf("Stats = %+v", st)
+	}
+}
+
+func TestSupervisorWaitTimeout(t *testing.T) {
+	s := newTestSupervisor(Never, nil)
+	release := make(chan struct{})
+	// This worker ignores its context, so only release ends it
+	s.Go("stuck", func(context.Context) error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait with a done context = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	if err := s.Wait(context.Background()); err != nil {
+		t.Errorf("Wait after release = %v", err)
+	}
+}
+
+func TestSupervisorStatsByWorker(t *testing.T) {
+	var clock instant
+	var (
+		mu     sync.Mutex
+		panics []string
+	)
+	s := newTestSupervisor(Times(1), clock.After)
+	s.OnPanic = func(name string, err *PanicError) {
+		mu.Lock()
+		panics = append(panics, name)
+		mu.Unlock()
+	}
+	s.Go("a", panicky(1))
+	s.Go("b", panicky(-1))
+	s.Go("c", panicky(0))
+	err := s.Wait(context.Background())
+	if err == nil {
+		t.Fatal("Wait = nil, want b's panic")
+	}
+
+	st := s.Stats()
+	want := map[string]int{"a": 1, "b": 2}
+	if !maps.Equal(st.ByWorker, want) {
+		t.Errorf("ByWorker = %v, want %v", st.ByWorker, want)
+	}
+	if st.Started != 3 || st.Panics != 3 || st.Restarts != 2 || st.GaveUp != 1 {
+		t.Errorf("Stats = %+v", st)
+	}
+	slices.Sort(panics)
+	if !slices.Equal(panics, []string{"a", "b", "b"}) {
+		t.Errorf("OnPanic saw %v", panics)
+	}
+
+	// Stats returns a copy
+	st.ByWorker["a"] = 100
+	if s.Stats().ByWorker["a"] != 1 {
+		t.Error("changing a Stats snapshot changed the supervisor")
+	}
+}
+
+func TestDefaultBackoff(t *testing.T) {
+	tests := map[int]time.Duration{
+		1:    10 * time.Millisecond,
+		2:    20 * time.Millisecond,
+		7:    640 * time.Millisecond,
+		8:    time.Second,
+		1000: time.Second,
+	}
+	for restart, want := range tests {
+		if got := DefaultBackoff(restart); got != want {
+			t.Errorf("DefaultBackoff(%d) = %v, want %v", restart, got, want)
+		}
+	}
+}
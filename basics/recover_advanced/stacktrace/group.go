@@ -0,0 +1,53 @@
+package stacktrace
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Bucket is a set of goroutines with the same state and identical stacks,
+// as found in dumps of servers with many idle workers.
+type Bucket struct {
+	State     string
+	Frames    []Frame
+	CreatedBy *Frame
+	IDs       []int // in the order they appeared
+}
+
+// Group buckets goroutines whose state, frames and creator match, ignoring
+// arguments, which usually differ. Bigger buckets come first; buckets of
+// the same size keep the order their first goroutine appeared in.
+func Group(gs []*Goroutine) []*Bucket {
+	var buckets []*Bucket
+	byKey := make(map[string]*Bucket)
+	for _, g := range gs {
+		k := key(g)
+		b, ok := byKey[k]
+		if !ok {
+			b = &Bucket{State: g.State, Frames: g.Frames, CreatedBy: g.CreatedBy}
+			byKey[k] = b
+			buckets = append(buckets, b)
+		}
+		b.IDs = append(b.IDs, g.ID)
+	}
+	slices.SortStableFunc(buckets, func(a, b *Bucket) int {
+		return cmp.Compare(len(b.IDs), len(a.IDs))
+	})
+	return buckets
+}
+
+// key identifies a goroutine's stack for grouping.
+func key(g *Goroutine) string {
+	var b strings.Builder
+	b.WriteString(g.State)
+	frames := g.Frames
+	if g.CreatedBy != nil {
+		frames = append(slices.Clip(frames), *g.CreatedBy)
+	}
+	for _, f := range frames {
+		fmt.Fprintf(&b, "\n%s %s:%d", f.Function, f.File, f.Line)
+	}
+	return b.String()
+}
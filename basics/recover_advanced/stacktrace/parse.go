@@ -0,0 +1,146 @@
+package stacktrace
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoGoroutines is returned by Parse when the input has no goroutine
+// headers.
+var ErrNoGoroutines = errors.New("stacktrace: no goroutines found")
+
+// header matches the line that starts a goroutine, with the extra fields
+// that GOTRACEBACK=system adds:
+//
+//	goroutine 7 [chan receive, 2 minutes]:
+//	goroutine 1 gp=0xc000002380 m=0 mp=0x5e6f40 [running]:
+var header = regexp.MustCompile(`^goroutine (\d+)(?: gp=\S+)?(?: m=\S+)?(?: mp=\S+)? \[(.*)\]:$`)
+
+// createdBy matches the line naming a goroutine's creator:
+//
+//	created by main.main in goroutine 1
+var createdBy = regexp.MustCompile(`^created by (\S+?)(?: in goroutine (\d+))?$`)
+
+// call matches a function call line, whose name has no spaces even though
+// its arguments may:
+//
+//	main.(*T).run(0xc000010000, {0x4b2f60, 0x3})
+var call = regexp.MustCompile(`^\S+\(.*\)$`)
+
+// Parse parses every goroutine in dump. Text before the first goroutine,
+// such as the "panic: ..." line of a crash, is ignored, and so is
+// anything after a goroutine that is not part of a stack, such as the
+// "exit status 2" that go run prints. A line is taken as a frame only if
+// a tab-indented location follows it or it looks like a function call.
+func Parse(dump []byte) ([]*Goroutine, error) {
+	var gs []*Goroutine
+	var g *Goroutine
+	lines := strings.Split(strings.ReplaceAll(string(dump), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if m := header.FindStringSubmatch(line); m != nil {
+			g = newGoroutine(m[1], m[2])
+			gs = append(gs, g)
+			continue
+		}
+		if g == nil || line == "" || strings.HasPrefix(line, "\t") {
+			if line == "" {
+				g = nil // a blank line ends the goroutine
+			}
+			continue
+		}
+		if line == "...additional frames elided..." {
+			g.Elided = true
+			continue
+		}
+
+		// A call is followed by its location on the next line
+		var loc string
+		if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\t") {
+			i++
+			loc = lines[i]
+		} else if !call.MatchString(line) && !createdBy.MatchString(line) {
+			g = nil // not a stack, so the goroutine has ended
+			continue
+		}
+		f, err := parseFrame(line, loc)
+		if err != nil {
+			return nil, err
+		}
+		if m := createdBy.FindStringSubmatch(line); m != nil {
+			f.Function = m[1]
+			g.CreatedBy = &f
+			g.CreatorID, _ = strconv.Atoi(m[2])
+			continue
+		}
+		g.Frames = append(g.Frames, f)
+	}
+	if len(gs) == 0 {
+		return nil, ErrNoGoroutines
+	}
+	return gs, nil
+}
+
+func newGoroutine(id, status string) *Goroutine {
+	g := &Goroutine{}
+	g.ID, _ = strconv.Atoi(id)
+	for i, part := range strings.Split(status, ", ") {
+		switch {
+		case i == 0:
+			g.State = part
+		case part == "locked to thread":
+			g.Locked = true
+		case strings.HasSuffix(part, "minutes") || strings.HasSuffix(part, "minute"):
+			g.Wait = part
+		}
+	}
+	return g
+}
+
+// parseFrame parses a call line and the tab-indented location line below
+// it:
+//
+//	main.(*T).run(0xc000010000, {0x4b2f60, 0x3})
+//		/src/main.go:12 +0x1d
+func parseFrame(call, loc string) (Frame, error) {
+	var f Frame
+	f.Function = call
+	if strings.HasSuffix(call, ")") {
+		// Find the parenthesis that opens the argument list, skipping any
+		// nested ones so method receivers like (*T) stay in the name
+		depth := 0
+		for i := len(call) - 1; i >= 0; i-- {
+			switch call[i] {
+			case ')':
+				depth++
+			case '(':
+				depth--
+			}
+			if depth == 0 {
+				f.Function, f.Args = call[:i], call[i+1:len(call)-1]
+				break
+			}
+		}
+	}
+
+	loc = strings.TrimSpace(loc)
+	if loc == "" {
+		return f, nil
+	}
+	if i := strings.LastIndex(loc, " +0x"); i >= 0 {
+		loc = loc[:i]
+	}
+	i := strings.LastIndex(loc, ":")
+	if i < 0 {
+		return Frame{}, fmt.Errorf("stacktrace: bad location %q", loc)
+	}
+	line, err := strconv.Atoi(loc[i+1:])
+	if err != nil {
+		return Frame{}, fmt.Errorf("stacktrace: bad line number in %q", loc)
+	}
+	f.File, f.Line = loc[:i], line
+	return f, nil
+}
@@ -0,0 +1,95 @@
+package stacktrace
+
+import (
+	"errors"
+	"testing"
+)
+
+const crash = `panic: boom
+
+goroutine 1 [running]:
+main.(*T).run(0xc000010000, {0x4b2f60, 0x3})
+	/src/main.go:12 +0x1d
+main.main()
+	/src/main.go:20 +0x25
+
+goroutine 7 gp=0xc000002380 m=0 mp=0x5e6f40 [chan receive, 2 minutes, locked to thread]:
+main.worker(...)
+	/src/worker.go:8
+...additional frames elided...
+created by main.main in goroutine 1
+	/src/main.go:18 +0x45
+exit status 2
+`
+
+func TestParse(t *testing.T) {
+	gs, err := Parse([]byte(crash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gs) != 2 {
+		t.Fatalf("parsed %d goroutines, want 2", len(gs))
+	}
+
+	g := gs[0]
+	want := []Frame{
+		{Function: "main.(*T).run", Args: "0xc000010000, {0x4b2f60, 0x3}", File: "/src/main.go", Line: 12},
+		{Function: "main.main", File: "/src/main.go", Line: 20},
+	}
+	if g.ID != 1 || g.State != "running" || len(g.Frames) != len(want) {
+		t.Fatalf("goroutine 1 = %+v", g)
+	}
+	for i, f := range want {
+		if g.Frames[i] != f {
+			t.Errorf("frame %d = %+v, want %+v", i, g.Frames[i], f)
+		}
+	}
+
+	g = gs[1]
+	if g.ID != 7 || g.State != "chan receive" || g.Wait != "2 minutes" || !g.Locked || !g.Elided {
+		t.Errorf("goroutine 7 = %+v", g)
+	}
+	// "exit status 2" after the stack is not a frame
+	if len(g.Frames) != 1 || g.Frames[0] != (Frame{Function: "main.worker", Args: "...", File: "/src/worker.go", Line: 8}) {
+		t.Errorf("goroutine 7 frames = %+v", g.Frames)
+	}
+	if g.CreatedBy == nil || g.CreatedBy.Function != "main.main" || g.CreatedBy.Line != 18 || g.CreatorID != 1 {
+		t.Errorf("goroutine 7 created by %+v in %d", g.CreatedBy, g.CreatorID)
+	}
+}
+
+func TestParseIgnoresTrailingText(t *testing.T) {
+	dump := "goroutine 1 [running]:\n" +
+		"main.main()\n" +
+		"\t/src/main.go:5 +0x1\n" +
+		"exit status 2\n" +
+		"some.func(looking, text)\n" +
+		"\t/src/other.go:1\n"
+	gs, err := Parse([]byte(dump))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gs) != 1 || len(gs[0].Frames) != 1 || gs[0].Frames[0].Function != "main.main" {
+		t.Errorf("Parse = %+v", gs[0])
+	}
+}
+
+func TestParseCallWithoutLocation(t *testing.T) {
+	// A truncated dump may lose the last location; the call still counts
+	gs, err := Parse([]byte("goroutine 3 [select]:\nmain.loop(0x1)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := gs[0].Frames; len(f) != 1 || f[0] != (Frame{Function: "main.loop", Args: "0x1"}) {
+		t.Errorf("frames = %+v", f)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse([]byte("exit status 2\n")); !errors.Is(err, ErrNoGoroutines) {
+		t.Errorf("Parse without goroutines: error = %v, want ErrNoGoroutines", err)
+	}
+	if _, err := Parse([]byte("goroutine 1 [running]:\nmain.main()\n\t/src/main.go:x\n")); err == nil {
+		t.Error("Parse with a bad line number succeeded")
+	}
+}
@@ -0,0 +1,102 @@
+package stacktrace
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Options controls Render.
+type Options struct {
+	// Color adds ANSI colours: program frames stand out and runtime frames
+	// are dimmed.
+	Color bool
+	// Runtime keeps runtime frames, which are left out by default.
+	Runtime bool
+	// FullPaths prints whole file paths instead of just the file name.
+	FullPaths bool
+}
+
+// ANSI escape sequences used when Options.Color is set.
+const (
+	bold  = "\x1b[1m"
+	dim   = "\x1b[2m"
+	cyan  = "\x1b[36m"
+	green = "\x1b[32m"
+	reset = "\x1b[0m"
+)
+
+// Render prints buckets compactly, one line per frame with the location
+// in an aligned column:
+//
+//	3 goroutines [chan receive]: 6, 7, 8
+//	    main.go:21  main.worker
+//	    main.go:30  created by main.main
+func Render(buckets []*Bucket, opts Options) string {
+	var b strings.Builder
+	for i, bk := range buckets {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		renderBucket(&b, bk, opts)
+	}
+	return b.String()
+}
+
+func renderBucket(b *strings.Builder, bk *Bucket, opts Options) {
+	ids := make([]string, len(bk.IDs))
+	for i, id := range bk.IDs {
+		ids[i] = fmt.Sprint(id)
+	}
+	noun := "goroutine"
+	if len(ids) > 1 {
+		noun = "goroutines"
+	}
+	head := fmt.Sprintf("%d %s [%s]: %s", len(ids), noun, bk.State, strings.Join(ids, ", "))
+	b.WriteString(paint(opts, bold, head) + "\n")
+
+	type row struct {
+		loc, name string
+		runtime   bool
+	}
+	var rows []row
+	frames := bk.Frames
+	if !opts.Runtime {
+		frames = FilterRuntime(frames)
+	}
+	for _, f := range frames {
+		rows = append(rows, row{location(f, opts), f.Function, f.IsRuntime()})
+	}
+	if bk.CreatedBy != nil {
+		rows = append(rows, row{location(*bk.CreatedBy, opts), "created by " + bk.CreatedBy.Function, false})
+	}
+
+	width := 0
+	for _, r := range rows {
+		width = max(width, len(r.loc))
+	}
+	for _, r := range rows {
+		loc := fmt.Sprintf("%-*s", width, r.loc)
+		if r.runtime {
+			b.WriteString("    " + paint(opts, dim, loc+"  "+r.name) + "\n")
+			continue
+		}
+		b.WriteString("    " + paint(opts, cyan, loc) + "  " + paint(opts, green, r.name) + "\n")
+	}
+}
+
+func location(f Frame, opts Options) string {
+	file := f.File
+	if !opts.FullPaths {
+		file = filepath.Base(file)
+	}
+	return fmt.Sprintf("%s:%d", file, f.Line)
+}
+
+// paint wraps s in an ANSI style if colour is on.
+func paint(opts Options, style, s string) string {
+	if !opts.Color {
+		return s
+	}
+	return style + s + reset
+}
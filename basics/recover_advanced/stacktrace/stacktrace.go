@@ -0,0 +1,123 @@
+// Package stacktrace parses Go goroutine dumps, such as the output of
+// debug.Stack, runtime.Stack or an unrecovered panic, into structured
+// frames. It can drop runtime frames, group goroutines with identical
+// stacks and render the result compactly.
+package stacktrace
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Frame is one function call in a goroutine's stack.
+type Frame struct {
+	Function string // fully qualified, such as "main.(*Server).handle"
+	Args     string // raw argument words, without the parentheses
+	File     string
+	Line     int
+}
+
+// Package returns the import path of the package that declares f's
+// function, such as "net/http" for "net/http.(*conn).serve".
+//
+// The package ends at the first dot after the last slash. Go escapes the
+// dots in the last element of an import path when naming functions, so
+// gopkg.in/yaml.v3 appears as "gopkg.in/yaml%2ev3.Unmarshal"; Package
+// undoes the escaping. Names written by hand without it, such as
+// "gopkg.in/yaml.v3.Unmarshal", are also understood when the dot starts
+// a major version suffix.
+func (f Frame) Package() string {
+	pkg := f.Function[:f.pkgEnd()]
+	if p, err := url.PathUnescape(pkg); err == nil {
+		return p
+	}
+	return pkg
+}
+
+// Name returns f's function name without its package path.
+func (f Frame) Name() string {
+	end := f.pkgEnd()
+	if end == 0 {
+		return f.Function
+	}
+	return f.Function[end+1:]
+}
+
+// pkgEnd returns the length of the package path at the start of
+// f.Function, as it is written there, or 0 if there is none.
+func (f Frame) pkgEnd() int {
+	fn := f.Function
+	// Type arguments of a generic function can contain slashes and dots
+	// of their own
+	if i := strings.IndexByte(fn, '['); i >= 0 {
+		fn = fn[:i]
+	}
+	slash := strings.LastIndex(fn, "/")
+	end := slash + 1
+	for {
+		dot := strings.IndexByte(fn[end:], '.')
+		if dot < 0 {
+			return 0
+		}
+		end += dot
+		if slash < 0 || !isVersionSuffix(fn[end+1:]) {
+			return end
+		}
+		end++
+	}
+}
+
+// isVersionSuffix reports whether s starts with a major version element,
+// such as "v3", that is followed by more of the name.
+func isVersionSuffix(s string) bool {
+	if len(s) < 3 || s[0] != 'v' {
+		return false
+	}
+	i := 1
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	return i > 1 && i < len(s) && s[i] == '.'
+}
+
+// IsRuntime reports whether f belongs to the Go runtime rather than to the
+// program, including the pseudo-frame "panic" that marks where a panic
+// started unwinding.
+func (f Frame) IsRuntime() bool {
+	if f.Function == "panic" {
+		return true
+	}
+	pkg := f.Package()
+	return pkg == "runtime" || strings.HasPrefix(pkg, "runtime/") || strings.HasPrefix(pkg, "internal/runtime/")
+}
+
+// Goroutine is one goroutine from a dump.
+type Goroutine struct {
+	ID     int
+	State  string // such as "running" or "chan receive"
+	Wait   string // how long it has been blocked, such as "2 minutes"; often empty
+	Locked bool   // locked to its OS thread
+	Frames []Frame
+	// Elided is set when the runtime left out frames from a very deep stack.
+	Elided bool
+	// CreatedBy is the go statement that started the goroutine, or nil for
+	// the main goroutine. CreatorID is the goroutine that ran it, if known.
+	CreatedBy *Frame
+	CreatorID int
+}
+
+// UserFrames returns g's frames without runtime frames.
+func (g *Goroutine) UserFrames() []Frame {
+	return FilterRuntime(g.Frames)
+}
+
+// FilterRuntime returns the frames that are not runtime frames.
+func FilterRuntime(frames []Frame) []Frame {
+	var out []Frame
+	for _, f := range frames {
+		if !f.IsRuntime() {
+			out = append(out, f)
+		}
+	}
+	return out
+}
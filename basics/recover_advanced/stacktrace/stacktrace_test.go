@@ -0,0 +1,153 @@
+package stacktrace
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFrameNames(t *testing.T) {
+	tests := []struct {
+		function, pkg, name string
+		runtime             bool
+	}{
+		{"main.main", "main", "main", false},
+		{"main.(*T).run.func1", "main", "(*T).run.func1", false},
+		{"net/http.(*conn).serve", "net/http", "(*conn).serve", false},
+		{"gopkg.in/yaml%2ev3.Unmarshal", "gopkg.in/yaml.v3", "Unmarshal", false},
+		{"gopkg.in/yaml.v3.Unmarshal", "gopkg.in/yaml.v3", "Unmarshal", false},
+		{"gopkg.in/yaml.v3.(*parser).parse", "gopkg.in/yaml.v3", "(*parser).parse", false},
+		{"example.com/m.Map[...]", "example.com/m", "Map[...]", false},
+		{"example.com/m.Map[go.shape.*example.com/x.T]", "example.com/m", "Map[go.shape.*example.com/x.T]", false},
+		{"runtime.gopark", "runtime", "gopark", true},
+		{"runtime/debug.Stack", "runtime/debug", "Stack", true},
+		{"internal/runtime/maps.fatal", "internal/runtime/maps", "fatal", true},
+		{"runtimex.F", "runtimex", "F", false},
+		{"example.com/runtime.F", "example.com/runtime", "F", false},
+		{"panic", "", "panic", true},
+	}
+	for _, tt := range tests {
+		f := Frame{Function: tt.function}
+		if got := f.Package(); got != tt.pkg {
+			t.Errorf("Package(%q) = %q, want %q", tt.function, got, tt.pkg)
+		}
+		if got := f.Name(); got != tt.name {
+			t.Errorf("Name(%q) = %q, want %q", tt.function, got, tt.name)
+		}
+		if got := f.IsRuntime(); got != tt.runtime {
+			t.Errorf("IsRuntime(%q) = %v, want %v", tt.function, got, tt.runtime)
+		}
+	}
+}
+
+func TestFilterRuntime(t *testing.T) {
+	frames := []Frame{
+		{Function: "panic"},
+		{Function: "runtime.sigpanic"},
+		{Function: "main.deref"},
+		{Function: "runtime/debug.Stack"},
+		{Function: "main.main"},
+	}
+	got := FilterRuntime(frames)
+	want := []Frame{{Function: "main.deref"}, {Function: "main.main"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("FilterRuntime = %v, want %v", got, want)
+	}
+	if got := (&Goroutine{Frames: frames}).UserFrames(); !slices.Equal(got, want) {
+		t.Errorf("UserFrames = %v, want %v", got, want)
+	}
+}
+
+// worker is a goroutine parked in main.worker, created at line
+// createdAt, with args that differ from goroutine to goroutine.
+func worker(id int, state, args string, createdAt int) *Goroutine {
+	return &Goroutine{
+		ID:    id,
+		State: state,
+		Frames: []Frame{
+			{Function: "runtime.gopark", File: "/go/src/runtime/proc.go", Line: 435},
+			{Function: "main.worker", Args: args, File: "/src/main.go", Line: 21},
+		},
+		CreatedBy: &Frame{Function: "main.main", File: "/src/main.go", Line: createdAt},
+		CreatorID: 1,
+	}
+}
+
+func TestGroup(t *testing.T) {
+	mainG := &Goroutine{ID: 1, State: "running", Frames: []Frame{{Function: "main.main", File: "/src/main.go", Line: 40}}}
+	gs := []*Goroutine{
+		mainG,
+		worker(6, "select", "0x1", 30),
+		worker(7, "chan receive", "0x1", 30),
+		worker(8, "chan receive", "0x2", 30), // args are ignored
+		worker(9, "chan receive", "0x3", 31), // another go statement
+		worker(10, "chan receive", "0x4", 30),
+	}
+	buckets := Group(gs)
+	var got [][]int
+	for _, b := range buckets {
+		got = append(got, b.IDs)
+	}
+	// Biggest first; equal sizes keep the order they were first seen
+	want := [][]int{{7, 8, 10}, {1}, {6}, {9}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("Group IDs = %v, want %v", got, want)
+	}
+	if b := buckets[0]; b.State != "chan receive" || b.CreatedBy.Line != 30 || len(b.Frames) != 2 {
+		t.Errorf("first bucket = %+v", b)
+	}
+	// Grouping must not write the creator into a goroutine's frames
+	if len(gs[1].Frames) != 2 {
+		t.Errorf("Group changed the frames of goroutine 6: %v", gs[1].Frames)
+	}
+	if Group(nil) != nil {
+		t.Error("Group(nil) is not empty")
+	}
+}
+
+func TestRender(t *testing.T) {
+	buckets := Group([]*Goroutine{
+		worker(7, "chan receive", "", 30),
+		worker(8, "chan receive", "", 30),
+		{ID: 1, State: "running", Frames: []Frame{{Function: "main.main", File: "/src/cmd/main.go", Line: 112}}},
+	})
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"default", Options{}, "" +
+			"2 goroutines [chan receive]: 7, 8\n" +
+			"    main.go:21  main.worker\n" +
+			"    main.go:30  created by main.main\n" +
+			"\n" +
+			"1 goroutine [running]: 1\n" +
+			"    main.go:112  main.main\n"},
+		{"runtime and full paths", Options{Runtime: true, FullPaths: true}, "" +
+			"2 goroutines [chan receive]: 7, 8\n" +
+			"    /go/src/runtime/proc.go:435  runtime.gopark\n" +
+			"    /src/main.go:21              main.worker\n" +
+			"    /src/main.go:30              created by main.main\n" +
+			"\n" +
+			"1 goroutine [running]: 1\n" +
+			"    /src/cmd/main.go:112  main.main\n"},
+	}
+	for _, tt := range tests {
+		if got := Render(buckets, tt.opts); got != tt.want {
+			t.Errorf("%s: Render =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	got := Render(Group([]*Goroutine{worker(7, "select", "", 30)}), Options{Color: true, Runtime: true})
+	for _, want := range []string{
+		bold + "1 goroutine [select]: 7" + reset,
+		dim + "proc.go:435  runtime.gopark" + reset,
+		cyan + "main.go:21 " + reset + "  " + green + "main.worker" + reset,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render with colour is missing %q:\n%q", want, got)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+    "fmt"
+
+    "dsa/viz"
+)
 
 // ArrayList is a dynamic array implementation in Go
 type ArrayList struct {
@@ -49,6 +53,21 @@ func printArray(arr *ArrayList) {
     fmt.Println()
 }
 
+// Len returns the number of elements currently in the ArrayList
+func (ls *ArrayList) Len() int {
+    return ls.size
+}
+
+// Cap returns the number of allocated slots, used or not
+func (ls *ArrayList) Cap() int {
+    return ls.capacity
+}
+
+// At returns the element at index i
+func (ls *ArrayList) At(i int) any {
+    return ls.data[i]
+}
+
 func main() {
     fmt.Println("ArrayList in Go")
 
@@ -62,4 +81,7 @@ func main() {
 
     // Print the array
     printArray(arr)
+
+    // Draw the array, including its unused capacity
+    fmt.Print(viz.ASCII(arr))
 }
\ No newline at end of file
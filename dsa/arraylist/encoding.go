@@ -0,0 +1,71 @@
+package main
+
+import (
+    "bytes"
+    "encoding/gob"
+    "encoding/json"
+
+    "dsa/internal/wire"
+)
+
+// values returns a copy of the live elements, leaving out spare capacity
+func (ls *ArrayList) values() []int {
+    return append([]int(nil), ls.data[:ls.size]...)
+}
+
+// reset replaces the contents of the ArrayList with values, in order
+func (ls *ArrayList) reset(values []int) {
+    *ls = *newDynamicArray()
+    for _, v := range values {
+        ls.insert(v)
+    }
+}
+
+// MarshalJSON encodes the live elements as a JSON array
+func (ls *ArrayList) MarshalJSON() ([]byte, error) {
+    return json.Marshal(ls.values())
+}
+
+// UnmarshalJSON replaces the contents with the elements of a JSON array
+func (ls *ArrayList) UnmarshalJSON(data []byte) error {
+    var values []int
+    if err := json.Unmarshal(data, &values); err != nil {
+        return err
+    }
+    ls.reset(values)
+    return nil
+}
+
+// GobEncode encodes the live elements with encoding/gob
+func (ls *ArrayList) GobEncode() ([]byte, error) {
+    var buf bytes.Buffer
+    if err := gob.NewEncoder(&buf).Encode(ls.values()); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+// GobDecode replaces the contents with elements encoded by GobEncode
+func (ls *ArrayList) GobDecode(data []byte) error {
+    var values []int
+    if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+        return err
+    }
+    ls.reset(values)
+    return nil
+}
+
+// MarshalBinary encodes the live elements in the compact wire format
+func (ls *ArrayList) MarshalBinary() ([]byte, error) {
+    return wire.AppendInts(nil, ls.values()), nil
+}
+
+// UnmarshalBinary replaces the contents with elements encoded by MarshalBinary
+func (ls *ArrayList) UnmarshalBinary(data []byte) error {
+    values, err := wire.Ints(data)
+    if err != nil {
+        return err
+    }
+    ls.reset(values)
+    return nil
+}
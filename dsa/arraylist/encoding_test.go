@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"dsa/internal/wiretest"
+)
+
+func FuzzArrayListRoundTrip(f *testing.F) {
+	wiretest.FuzzRoundTrip(f, func(values []int) (*ArrayList, bool) {
+		ls := newDynamicArray()
+		ls.reset(values)
+		return ls, true
+	}, (*ArrayList).values)
+}
@@ -0,0 +1,45 @@
+// Package wire holds the compact binary format shared by the int containers
+// in this module: the element count as a uvarint followed by each element as
+// a zig-zag varint.
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrCorrupt is returned when the input is not a valid encoding.
+var ErrCorrupt = errors.New("wire: corrupt input")
+
+// AppendInts appends the encoding of values to b.
+func AppendInts(b []byte, values []int) []byte {
+	b = binary.AppendUvarint(b, uint64(len(values)))
+	for _, v := range values {
+		b = binary.AppendVarint(b, int64(v))
+	}
+	return b
+}
+
+// Ints decodes data produced by AppendInts. Trailing bytes are an error.
+func Ints(data []byte) ([]int, error) {
+	n, k := binary.Uvarint(data)
+	// Every element takes at least one byte, so a larger count is a lie and
+	// must not be used to size the allocation.
+	if k <= 0 || n > uint64(len(data)-k) {
+		return nil, ErrCorrupt
+	}
+	data = data[k:]
+	values := make([]int, n)
+	for i := range values {
+		v, k := binary.Varint(data)
+		if k <= 0 || int64(int(v)) != v {
+			return nil, ErrCorrupt
+		}
+		values[i] = int(v)
+		data = data[k:]
+	}
+	if len(data) != 0 {
+		return nil, ErrCorrupt
+	}
+	return values, nil
+}
@@ -0,0 +1,57 @@
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"dsa/internal/wiretest"
+)
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(binary.LittleEndian.AppendUint64(nil, math.MaxUint64))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		values := wiretest.Ints(data)
+		got, err := Ints(AppendInts(nil, values))
+		if err != nil {
+			t.Fatalf("Ints(AppendInts(%v)): %v", values, err)
+		}
+		if !slices.Equal(got, values) && len(got)+len(values) > 0 {
+			t.Fatalf("round trip: got %v, want %v", got, values)
+		}
+	})
+}
+
+// FuzzInts feeds arbitrary bytes to Ints, which must reject them cleanly
+// rather than panic or allocate more elements than there are bytes.
+func FuzzInts(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0})
+	f.Add(AppendInts(nil, []int{1, -2, math.MaxInt, math.MinInt}))
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}) // huge count
+	f.Add([]byte{2, 1})                                                       // count larger than the elements
+	f.Add([]byte{1, 2, 3})                                                    // trailing bytes
+	f.Add([]byte{1, 0x80})                                                    // truncated varint
+	f.Fuzz(func(t *testing.T, data []byte) {
+		values, err := Ints(data)
+		if err != nil {
+			if !errors.Is(err, ErrCorrupt) {
+				t.Fatalf("Ints(%x) returned %v, want ErrCorrupt", data, err)
+			}
+			if values != nil {
+				t.Fatalf("Ints(%x) returned values with an error", data)
+			}
+			return
+		}
+		if cap(values) > len(data) {
+			t.Fatalf("Ints(%x) allocated %d elements from %d bytes", data, cap(values), len(data))
+		}
+		again, err := Ints(AppendInts(nil, values))
+		if err != nil || !slices.Equal(again, values) {
+			t.Fatalf("re-encoding %v gave %v, %v", values, again, err)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+// Package wiretest holds the fuzz test shared by the int containers in this
+// module, which all encode the same way: as JSON, with encoding/gob and in
+// the wire format.
+package wiretest
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// Ints turns fuzz input into ints, eight bytes at a time, so the corpus
+// reaches the extremes of the int range.
+func Ints(data []byte) []int {
+	var values []int
+	for ; len(data) >= 8; data = data[8:] {
+		values = append(values, int(binary.LittleEndian.Uint64(data)))
+	}
+	return values
+}
+
+// AddSeeds adds inputs that Ints turns into no values, one small value, and
+// the extremes of the int range.
+func AddSeeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(binary.LittleEndian.AppendUint64(nil, 42))
+	f.Add(slices.Concat(
+		binary.LittleEndian.AppendUint64(nil, 1<<63),
+		binary.LittleEndian.AppendUint64(nil, 1<<63-1),
+		binary.LittleEndian.AppendUint64(nil, 7)))
+}
+
+// Codec encodes in one way and decodes the result into out, which must be
+// a pointer.
+type Codec struct {
+	Name      string
+	RoundTrip func(in, out any) error
+}
+
+// Codecs lists every encoding the containers support.
+var Codecs = []Codec{
+	{"json", func(in, out any) error {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, out)
+	}},
+	{"gob", func(in, out any) error {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			return err
+		}
+		return gob.NewDecoder(&buf).Decode(out)
+	}},
+	{"binary", func(in, out any) error {
+		m, ok := in.(encoding.BinaryMarshaler)
+		if !ok {
+			return fmt.Errorf("%T is not a BinaryMarshaler", in)
+		}
+		data, err := m.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		u, ok := out.(encoding.BinaryUnmarshaler)
+		if !ok {
+			return fmt.Errorf("%T is not a BinaryUnmarshaler", out)
+		}
+		return u.UnmarshalBinary(data)
+	}},
+}
+
+// FuzzRoundTrip checks that a container survives every codec. build makes
+// a container holding the fuzzer's values, or reports false to skip values
+// it cannot hold; values reads a container back. Each codec decodes into a
+// new zero E.
+func FuzzRoundTrip[E any, T interface{ *E }](f *testing.F, build func([]int) (T, bool), values func(T) []int) {
+	AddSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		in, ok := build(Ints(data))
+		if !ok {
+			t.Skip()
+		}
+		want := values(in)
+		for _, c := range Codecs {
+			out := T(new(E))
+			if err := c.RoundTrip(in, out); err != nil {
+				t.Fatalf("%s: %v", c.Name, err)
+			}
+			if got := values(out); !slices.Equal(got, want) {
+				t.Fatalf("%s: got %v, want %v", c.Name, got, want)
+			}
+		}
+	})
+}
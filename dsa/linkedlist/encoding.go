@@ -0,0 +1,82 @@
+package main
+
+import (
+    "bytes"
+    "encoding/gob"
+    "encoding/json"
+    "errors"
+
+    "dsa/internal/wire"
+)
+
+// errEmptyList is returned when decoding zero elements into a Node.
+// An empty list is a nil *Node, which encodes as JSON null.
+var errEmptyList = errors.New("linkedlist: cannot decode an empty list into a Node")
+
+// values returns the data of every node from head to tail
+func (head *Node) values() []int {
+    var values []int
+    for current := head; current != nil; current = current.next {
+        values = append(values, current.data)
+    }
+    return values
+}
+
+// reset turns head into the first node of a new list holding values, in order
+func (head *Node) reset(values []int) error {
+    if len(values) == 0 {
+        return errEmptyList
+    }
+    var rest *Node
+    for i := len(values) - 1; i > 0; i-- {
+        rest = rest.appendToStartOfTheList(values[i])
+    }
+    *head = Node{values[0], rest}
+    return nil
+}
+
+// MarshalJSON encodes the list as a JSON array from head to tail
+func (head *Node) MarshalJSON() ([]byte, error) {
+    return json.Marshal(head.values())
+}
+
+// UnmarshalJSON replaces the list starting at head with the elements of a JSON array
+func (head *Node) UnmarshalJSON(data []byte) error {
+    var values []int
+    if err := json.Unmarshal(data, &values); err != nil {
+        return err
+    }
+    return head.reset(values)
+}
+
+// GobEncode encodes the list from head to tail with encoding/gob
+func (head *Node) GobEncode() ([]byte, error) {
+    var buf bytes.Buffer
+    if err := gob.NewEncoder(&buf).Encode(head.values()); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+// GobDecode replaces the list starting at head with elements encoded by GobEncode
+func (head *Node) GobDecode(data []byte) error {
+    var values []int
+    if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+        return err
+    }
+    return head.reset(values)
+}
+
+// MarshalBinary encodes the list from head to tail in the compact wire format
+func (head *Node) MarshalBinary() ([]byte, error) {
+    return wire.AppendInts(nil, head.values()), nil
+}
+
+// UnmarshalBinary replaces the list starting at head with elements encoded by MarshalBinary
+func (head *Node) UnmarshalBinary(data []byte) error {
+    values, err := wire.Ints(data)
+    if err != nil {
+        return err
+    }
+    return head.reset(values)
+}
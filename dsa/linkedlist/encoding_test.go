@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+
+	"dsa/internal/wiretest"
+)
+
+func FuzzLinkedListRoundTrip(f *testing.F) {
+	// An empty list is a nil *Node and cannot be decoded into, so it is
+	// skipped
+	wiretest.FuzzRoundTrip(f, func(values []int) (*Node, bool) {
+		head := new(Node)
+		return head, head.reset(values) == nil
+	}, (*Node).values)
+}
@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+    "fmt"
+
+    "dsa/viz"
+)
 
 // Node represents a node in the singly linked list
 type Node struct {
@@ -74,6 +78,19 @@ func (head *Node) printList() {
     fmt.Println("nil")
 }
 
+// Value returns the data stored in the node
+func (head *Node) Value() any {
+    return head.data
+}
+
+// Next returns the following node, or nil at the end of the list
+func (head *Node) Next() viz.Linked {
+    if head.next == nil {
+        return nil
+    }
+    return head.next
+}
+
 func main() {
     fmt.Println("Linked List Data Structure in GO Lang..!")
 
@@ -85,6 +102,7 @@ func main() {
         fmt.Println("3. Deletion at the beginning")
         fmt.Println("4. Deletion at the end")
         fmt.Println("5. Length of the list")
+        fmt.Println("6. Draw the list")
         fmt.Println("0. Exit")
         fmt.Print("Enter your choice: ")
 
@@ -113,6 +131,8 @@ func main() {
         case 5:
             fmt.Println("Length of the list is:", head.lengthOfList())
             head.printList()
+        case 6:
+            fmt.Print(viz.ASCII(head))
         case 0:
             fmt.Println("Exiting...")
             return
@@ -0,0 +1,71 @@
+package main
+
+import (
+    "bytes"
+    "encoding/gob"
+    "encoding/json"
+
+    "dsa/internal/wire"
+)
+
+// values returns a copy of the live elements from bottom to top, leaving out spare capacity
+func (st *Stack) values() []int {
+    return append([]int(nil), st.data[:st.top+1]...)
+}
+
+// reset replaces the contents of the stack with values, pushed in order
+func (st *Stack) reset(values []int) {
+    *st = *initializeStack()
+    for _, v := range values {
+        st.push(v)
+    }
+}
+
+// MarshalJSON encodes the live elements as a JSON array, bottom to top
+func (st *Stack) MarshalJSON() ([]byte, error) {
+    return json.Marshal(st.values())
+}
+
+// UnmarshalJSON replaces the contents with the elements of a JSON array
+func (st *Stack) UnmarshalJSON(data []byte) error {
+    var values []int
+    if err := json.Unmarshal(data, &values); err != nil {
+        return err
+    }
+    st.reset(values)
+    return nil
+}
+
+// GobEncode encodes the live elements with encoding/gob
+func (st *Stack) GobEncode() ([]byte, error) {
+    var buf bytes.Buffer
+    if err := gob.NewEncoder(&buf).Encode(st.values()); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+// GobDecode replaces the contents with elements encoded by GobEncode
+func (st *Stack) GobDecode(data []byte) error {
+    var values []int
+    if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+        return err
+    }
+    st.reset(values)
+    return nil
+}
+
+// MarshalBinary encodes the live elements in the compact wire format
+func (st *Stack) MarshalBinary() ([]byte, error) {
+    return wire.AppendInts(nil, st.values()), nil
+}
+
+// UnmarshalBinary replaces the contents with elements encoded by MarshalBinary
+func (st *Stack) UnmarshalBinary(data []byte) error {
+    values, err := wire.Ints(data)
+    if err != nil {
+        return err
+    }
+    st.reset(values)
+    return nil
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"dsa/internal/wiretest"
+)
+
+func FuzzStackRoundTrip(f *testing.F) {
+	wiretest.FuzzRoundTrip(f, func(values []int) (*Stack, bool) {
+		s := initializeStack()
+		s.reset(values)
+		return s, true
+	}, (*Stack).values)
+}
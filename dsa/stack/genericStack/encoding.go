@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// values returns a copy of the live elements from bottom to top, leaving out spare capacity
+func (st *Stack[T]) values() []T {
+	return append([]T(nil), st.data[:st.top+1]...)
+}
+
+// reset replaces the contents of the stack with values, pushed in order
+func (st *Stack[T]) reset(values []T) {
+	*st = initialiseStack[T]()
+	for _, v := range values {
+		st.push(v)
+	}
+}
+
+// MarshalJSON encodes the live elements as a JSON array, bottom to top
+func (st *Stack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(st.values())
+}
+
+// UnmarshalJSON replaces the contents with the elements of a JSON array
+func (st *Stack[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	st.reset(values)
+	return nil
+}
+
+// GobEncode encodes the live elements with encoding/gob
+func (st *Stack[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(st.values()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the contents with elements encoded by GobEncode
+func (st *Stack[T]) GobDecode(data []byte) error {
+	var values []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+	st.reset(values)
+	return nil
+}
+
+// MarshalBinary encodes the live elements. T can be any type, so unlike the
+// int containers this uses the self-describing gob format.
+func (st *Stack[T]) MarshalBinary() ([]byte, error) {
+	return st.GobEncode()
+}
+
+// UnmarshalBinary replaces the contents with elements encoded by MarshalBinary
+func (st *Stack[T]) UnmarshalBinary(data []byte) error {
+	return st.GobDecode(data)
+}
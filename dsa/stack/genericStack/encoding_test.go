@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// roundTrip encodes in with each encoding and checks that decoding gives
+// back the same elements.
+func roundTrip[T comparable](t *testing.T, values []T) {
+	t.Helper()
+	in := initialiseStack[T]()
+	in.reset(values)
+
+	codecs := []struct {
+		name string
+		run  func(out *Stack[T]) error
+	}{
+		{"json", func(out *Stack[T]) error {
+			data, err := json.Marshal(&in)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(data, out)
+		}},
+		{"gob", func(out *Stack[T]) error {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+				return err
+			}
+			return gob.NewDecoder(&buf).Decode(out)
+		}},
+		{"binary", func(out *Stack[T]) error {
+			data, err := in.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			return out.UnmarshalBinary(data)
+		}},
+	}
+	for _, c := range codecs {
+		var out Stack[T]
+		if err := c.run(&out); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := out.values(); !slices.Equal(got, in.values()) {
+			t.Fatalf("%s: got %v, want %v", c.name, got, in.values())
+		}
+		if out.Top() != in.Top() {
+			t.Fatalf("%s: top is %d, want %d", c.name, out.Top(), in.Top())
+		}
+	}
+}
+
+func FuzzIntStackRoundTrip(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 1, 255, 128})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		values := make([]int, len(data))
+		for i, b := range data {
+			values[i] = int(int8(b)) << (i % 57)
+		}
+		roundTrip(t, values)
+	})
+}
+
+func FuzzStringStackRoundTrip(f *testing.F) {
+	f.Add("")
+	f.Add("a,b,c")
+	f.Add("\xff, ,\"")
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip("JSON replaces invalid UTF-8, so only valid strings round-trip")
+		}
+		roundTrip(t, strings.Split(s, ","))
+	})
+}
@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"dsa/viz"
+)
 
 type Stack[T any] struct {
 	capacity int
@@ -49,6 +53,26 @@ func (st *Stack[T]) pop() T {
 	return poppedElement
 }
 
+// Len returns the number of elements on the stack
+func (st *Stack[T]) Len() int {
+	return st.top + 1
+}
+
+// Cap returns the number of allocated slots, used or not
+func (st *Stack[T]) Cap() int {
+	return st.capacity
+}
+
+// At returns the element at index i, counting from the bottom of the stack
+func (st *Stack[T]) At(i int) any {
+	return st.data[i]
+}
+
+// Top returns the index of the top element, or -1 if the stack is empty
+func (st *Stack[T]) Top() int {
+	return st.top
+}
+
 func main() {
 
 	stack := initialiseStack[int]()
@@ -57,6 +81,7 @@ func main() {
 	stack.push(10)
 	stack.push(20)
 	fmt.Println(stack.data) // Print stack data after pushing elements
+	fmt.Print(viz.ASCII(&stack)) // Draw the stack with its top index
 	fmt.Println("Popped element:", stack.pop()) // Pop an element
 	fmt.Println("Popped element:", stack.pop()) // Pop another element
 	fmt.Println("Popped element:", stack.pop()) // Attempt to pop from an empty stack
@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+    "fmt"
+
+    "dsa/viz"
+)
 
 // Stack struct represents a stack data structure
 type Stack struct {
@@ -64,6 +68,26 @@ func (st *Stack) pop() int {
     return poppedElement
 }
 
+// Len returns the number of elements on the stack
+func (st *Stack) Len() int {
+    return st.top + 1
+}
+
+// Cap returns the number of allocated slots, used or not
+func (st *Stack) Cap() int {
+    return st.capacity
+}
+
+// At returns the element at index i, counting from the bottom of the stack
+func (st *Stack) At(i int) any {
+    return st.data[i]
+}
+
+// Top returns the index of the top element, or -1 if the stack is empty
+func (st *Stack) Top() int {
+    return st.top
+}
+
 func main() {
     // Initialize a new stack
     stack := initializeStack()
@@ -85,4 +109,7 @@ func main() {
     // Push another element
     stack.push(60)
     fmt.Println(stack.data) // Print stack data after another push
+
+    // Draw the stack with its top index and unused slots
+    fmt.Print(viz.ASCII(stack))
 }
\ No newline at end of file
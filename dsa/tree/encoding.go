@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+
+	"dsa/internal/wire"
+)
+
+var (
+	// errEmptyTree is returned when decoding zero elements into a TreeNode.
+	// An empty tree is a nil *TreeNode, which encodes as JSON null.
+	errEmptyTree = errors.New("tree: cannot decode an empty tree into a TreeNode")
+
+	// errCorrupt is returned when the decoded ints do not describe a tree.
+	errCorrupt = errors.New("tree: corrupt encoding")
+)
+
+// Every encoding holds the nodes in preorder, two ints per node: its data,
+// then which children follow it, as the sum of hasLeft and hasRight. That
+// keeps the exact shape of any binary tree, whatever order its data is in.
+const (
+	hasLeft  = 1
+	hasRight = 2
+)
+
+// values returns the nodes of the tree in the order described above.
+func (root *TreeNode) values() []int {
+	var values []int
+	var walk func(n *TreeNode)
+	walk = func(n *TreeNode) {
+		if n == nil {
+			return
+		}
+		children := 0
+		if n.left != nil {
+			children += hasLeft
+		}
+		if n.right != nil {
+			children += hasRight
+		}
+		values = append(values, n.data, children)
+		walk(n.left)
+		walk(n.right)
+	}
+	walk(root)
+	return values
+}
+
+// reset turns root into the root of the tree that values describes
+func (root *TreeNode) reset(values []int) error {
+	if len(values) == 0 {
+		return errEmptyTree
+	}
+	var build func() (*TreeNode, error)
+	build = func() (*TreeNode, error) {
+		if len(values) < 2 || values[1]&^(hasLeft|hasRight) != 0 {
+			return nil, errCorrupt
+		}
+		n := &TreeNode{data: values[0]}
+		children := values[1]
+		values = values[2:]
+		var err error
+		if children&hasLeft != 0 {
+			if n.left, err = build(); err != nil {
+				return nil, err
+			}
+		}
+		if children&hasRight != 0 {
+			if n.right, err = build(); err != nil {
+				return nil, err
+			}
+		}
+		return n, nil
+	}
+	n, err := build()
+	if err != nil {
+		return err
+	}
+	if len(values) != 0 {
+		return errCorrupt
+	}
+	*root = *n
+	return nil
+}
+
+// MarshalJSON encodes the tree as a JSON array of ints in preorder
+func (root *TreeNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(root.values())
+}
+
+// UnmarshalJSON replaces the tree at root with one built from a JSON array
+func (root *TreeNode) UnmarshalJSON(data []byte) error {
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	return root.reset(values)
+}
+
+// GobEncode encodes the tree in preorder with encoding/gob
+func (root *TreeNode) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(root.values()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the tree at root with one encoded by GobEncode
+func (root *TreeNode) GobDecode(data []byte) error {
+	var values []int
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+	return root.reset(values)
+}
+
+// MarshalBinary encodes the tree in preorder in the compact wire format
+func (root *TreeNode) MarshalBinary() ([]byte, error) {
+	return wire.AppendInts(nil, root.values()), nil
+}
+
+// UnmarshalBinary replaces the tree at root with one encoded by MarshalBinary
+func (root *TreeNode) UnmarshalBinary(data []byte) error {
+	values, err := wire.Ints(data)
+	if err != nil {
+		return err
+	}
+	return root.reset(values)
+}
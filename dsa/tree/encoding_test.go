@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"dsa/internal/wiretest"
+)
+
+// grow builds a tree holding values. Each value after the first walks down
+// from the root, turning left or right by its own bits, and hangs at the
+// first free place, so the fuzzer reaches every shape, not just sorted ones.
+func grow(values []int) *TreeNode {
+	root := &TreeNode{data: values[0]}
+	for _, v := range values[1:] {
+		n, bits := root, uint(v)
+		for {
+			next := &n.right
+			if bits&1 == 0 {
+				next = &n.left
+			}
+			if *next == nil {
+				*next = &TreeNode{data: v}
+				break
+			}
+			n, bits = *next, bits>>1|bits<<63
+		}
+	}
+	return root
+}
+
+func FuzzTreeRoundTrip(f *testing.F) {
+	// An empty tree is a nil *TreeNode and cannot be decoded into, so it is
+	// skipped
+	wiretest.FuzzRoundTrip(f, func(values []int) (*TreeNode, bool) {
+		if len(values) == 0 {
+			return nil, false
+		}
+		return grow(values), true
+	}, (*TreeNode).values)
+}
+
+// FuzzTreeDecode feeds arbitrary bytes to UnmarshalBinary, which must
+// either reject them or decode a tree that survives another round trip.
+// The bytes themselves may come back different, since varints can be
+// padded.
+func FuzzTreeDecode(f *testing.F) {
+	f.Add([]byte{})
+	leaf, _ := (&TreeNode{data: 1}).MarshalBinary()
+	f.Add(leaf)
+	whole, _ := grow([]int{5, 2, 9, -4, 7}).MarshalBinary()
+	f.Add(whole)
+	f.Add(whole[:len(whole)-1])
+	f.Fuzz(func(t *testing.T, data []byte) {
+		root := new(TreeNode)
+		if root.UnmarshalBinary(data) != nil {
+			return
+		}
+		again, _ := root.MarshalBinary()
+		back := new(TreeNode)
+		if err := back.UnmarshalBinary(again); err != nil || !slices.Equal(back.values(), root.values()) {
+			t.Fatalf("%x decoded as %v, but its encoding %x decoded as %v, %v",
+				data, root.values(), again, back.values(), err)
+		}
+	})
+}
+
+func TestTreeEncoding(t *testing.T) {
+	// 50 has both children, 30 only a right one, 70 only a left one
+	root := &TreeNode{data: 50,
+		left:  &TreeNode{data: 30, right: &TreeNode{data: 40}},
+		right: &TreeNode{data: 70, left: &TreeNode{data: 60}},
+	}
+	data, err := json.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[50,3,30,2,40,0,70,1,60,0]`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+	if data, _ := json.Marshal((*TreeNode)(nil)); string(data) != "null" {
+		t.Errorf("Marshal of a nil tree = %s, want null", data)
+	}
+	for _, in := range []string{`[]`, `[1]`, `[1,4]`, `[1,1]`, `[1,0,2,0]`, `[1,-1]`} {
+		if err := json.Unmarshal([]byte(in), new(TreeNode)); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", in)
+		}
+	}
+}
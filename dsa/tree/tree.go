@@ -1,12 +1,46 @@
 package main
 
+import (
+	"fmt"
 
-import "fmt"
+	"dsa/viz"
+)
 
+// TreeNode represents a node in a binary tree
 type TreeNode struct {
-	
+	data  int
+	left  *TreeNode
+	right *TreeNode
+}
+
+// Value returns the data stored in the node
+func (root *TreeNode) Value() any {
+	return root.data
+}
+
+// Children returns the left and right children in that order, with nil for
+// a missing one so the drawing shows which side a lone child is on. A leaf
+// has no children.
+func (root *TreeNode) Children() []viz.Tree {
+	if root.left == nil && root.right == nil {
+		return nil
+	}
+	children := []viz.Tree{nil, nil}
+	if root.left != nil {
+		children[0] = root.left
+	}
+	if root.right != nil {
+		children[1] = root.right
+	}
+	return children
 }
 
 func main() {
+	root := &TreeNode{data: 50,
+		left:  &TreeNode{data: 30, right: &TreeNode{data: 40}},
+		right: &TreeNode{data: 70, left: &TreeNode{data: 60}, right: &TreeNode{data: 80}},
+	}
 
-}
\ No newline at end of file
+	// Draw the shape of the tree
+	fmt.Print(viz.ASCII(root))
+}
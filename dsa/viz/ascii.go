@@ -0,0 +1,155 @@
+package viz
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unused is drawn in slots that are allocated but hold no live element.
+const unused = "."
+
+// missing is drawn in place of an absent child in a tree.
+const missing = "·"
+
+// slotsASCII draws every allocated slot of s, live or not:
+//
+//	len=3 cap=4
+//	+----+----+----+----+
+//	| 10 | 20 | 30 | .  |
+//	+----+----+----+----+
+//	  0    1    2    3
+func slotsASCII(s Slots) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "len=%d cap=%d\n", s.Len(), s.Cap())
+	cells, width := slotCells(s)
+	writeSlots(&b, cells, width)
+	return b.String()
+}
+
+// stackASCII draws a stack like slotsASCII and marks the top element.
+func stackASCII(s Stacked) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "len=%d cap=%d top=%d\n", s.Len(), s.Cap(), s.Top())
+	cells, width := slotCells(s)
+	writeSlots(&b, cells, width)
+	if s.Top() < 0 {
+		b.WriteString("(empty)\n")
+	} else {
+		b.WriteString(strings.Repeat(" ", 2+s.Top()*(width+3)) + "^ top\n")
+	}
+	return b.String()
+}
+
+// slotCells returns the label of every slot and the widest label or index.
+func slotCells(s Slots) ([]string, int) {
+	cells := make([]string, s.Cap())
+	width := len(fmt.Sprint(s.Cap() - 1))
+	for i := range cells {
+		cells[i] = unused
+		if i < s.Len() {
+			cells[i] = fmt.Sprint(s.At(i))
+		}
+		width = max(width, len(cells[i]))
+	}
+	return cells, width
+}
+
+// writeSlots draws cells as a row of equal-width boxes with indexes below.
+func writeSlots(b *strings.Builder, cells []string, width int) {
+	border := "+" + strings.Repeat(strings.Repeat("-", width+2)+"+", len(cells)) + "\n"
+	if len(cells) == 0 {
+		border = "++\n"
+	}
+	b.WriteString(border)
+	if len(cells) == 0 {
+		b.WriteString("||\n" + border)
+		return
+	}
+	b.WriteString("|")
+	for _, c := range cells {
+		fmt.Fprintf(b, " %-*s |", width, c)
+	}
+	b.WriteString("\n")
+	b.WriteString(border)
+	var indexes strings.Builder
+	for i := range cells {
+		fmt.Fprintf(&indexes, "  %-*d ", width, i)
+	}
+	b.WriteString(strings.TrimRight(indexes.String(), " ") + "\n")
+}
+
+// listASCII draws each node as a box with an arrow to the next one:
+//
+//	+---+   +---+
+//	| 1 |-->| 2 |--> nil
+//	+---+   +---+
+//
+// A node that was already visited ends the drawing with a note instead of
+// looping forever.
+func listASCII(head Linked) string {
+	var labels []string
+	tail := "nil"
+	seen := make(map[Linked]int)
+	for n := head; !isNil(n); n = n.Next() {
+		if i, ok := seen[n]; ok {
+			tail = fmt.Sprintf("(cycle to node %d)", i)
+			break
+		}
+		seen[n] = len(labels)
+		labels = append(labels, fmt.Sprint(n.Value()))
+	}
+
+	var top, mid, bottom strings.Builder
+	for _, l := range labels {
+		border := "+" + strings.Repeat("-", len(l)+2) + "+"
+		top.WriteString(border + "   ")
+		mid.WriteString("| " + l + " |-->")
+		bottom.WriteString(border + "   ")
+	}
+	if len(labels) > 0 {
+		mid.WriteString(" ")
+	}
+	mid.WriteString(tail)
+	return lines(top.String(), mid.String(), bottom.String())
+}
+
+// treeASCII draws the tree rooted at root with one node per line. Missing
+// children are drawn as the placeholder:
+//
+//	50
+//	|-- 30
+//	|   |-- ·
+//	|   `-- 40
+//	`-- 70
+func treeASCII(root Tree) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprint(root.Value()) + "\n")
+	writeSubtrees(&b, root.Children(), "")
+	return b.String()
+}
+
+func writeSubtrees(b *strings.Builder, children []Tree, prefix string) {
+	for i, c := range children {
+		branch, indent := "|-- ", "|   "
+		if i == len(children)-1 {
+			branch, indent = "`-- ", "    "
+		}
+		if isNil(c) {
+			b.WriteString(prefix + branch + missing + "\n")
+			continue
+		}
+		b.WriteString(prefix + branch + fmt.Sprint(c.Value()) + "\n")
+		writeSubtrees(b, c.Children(), prefix+indent)
+	}
+}
+
+// lines joins rows with newlines, dropping trailing spaces and empty rows.
+func lines(rows ...string) string {
+	var b strings.Builder
+	for _, r := range rows {
+		if r = strings.TrimRight(r, " "); r != "" {
+			b.WriteString(r + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package viz
+
+import (
+	"fmt"
+	"strings"
+)
+
+// graph accumulates the statements of a DOT digraph.
+type graph struct {
+	name  string
+	stmts []string
+}
+
+func newGraph(name string) *graph {
+	if name == "" {
+		name = "G"
+	}
+	return &graph{name: name}
+}
+
+func (g *graph) node(id, attrs string) {
+	g.stmts = append(g.stmts, fmt.Sprintf("%s [%s];", id, attrs))
+}
+
+func (g *graph) edge(from, to, attrs string) {
+	stmt := from + " -> " + to
+	if attrs != "" {
+		stmt += " [" + attrs + "]"
+	}
+	g.stmts = append(g.stmts, stmt+";")
+}
+
+func (g *graph) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", g.name)
+	for _, s := range g.stmts {
+		b.WriteString("    " + s + "\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// record escapes s for use inside a record-shaped node label.
+func record(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "|", `\|`, "{", `\{`, "}", `\}`, "<", `\<`, ">", `\>`)
+	return r.Replace(s)
+}
+
+// slotsDOT draws s as a single record node with one field per slot.
+// Field i is addressable as slots:i so other nodes can point into it.
+func slotsDOT(g *graph, s Slots) {
+	g.stmts = append(g.stmts, "rankdir=LR;")
+	fields := make([]string, s.Cap())
+	for i := range fields {
+		label := unused
+		if i < s.Len() {
+			label = record(fmt.Sprint(s.At(i)))
+		}
+		fields[i] = fmt.Sprintf("<%d> %s", i, label)
+	}
+	g.node("slots", fmt.Sprintf(`shape=record, label="%s", xlabel="len=%d cap=%d"`,
+		strings.Join(fields, "|"), s.Len(), s.Cap()))
+}
+
+// stackDOT draws s like slotsDOT with a "top" pointer into the top slot.
+func stackDOT(g *graph, s Stacked) {
+	slotsDOT(g, s)
+	g.node("top", `shape=plaintext`)
+	if s.Top() < 0 {
+		g.node("empty", `shape=point`)
+		g.edge("top", "empty", "")
+		return
+	}
+	g.edge("top", fmt.Sprintf("slots:%d", s.Top()), "")
+}
+
+// listDOT draws one record node per list node, each with a pointer field
+// wired to its successor.
+func listDOT(g *graph, head Linked) {
+	g.stmts = append(g.stmts, "rankdir=LR;")
+	seen := make(map[Linked]string)
+	prev := "head"
+	g.node("head", `shape=plaintext`)
+	for n := head; ; n = n.Next() {
+		if isNil(n) {
+			g.node("nil", `shape=point`)
+			g.edge(prev, "nil", "")
+			return
+		}
+		if id, ok := seen[n]; ok {
+			g.edge(prev, id, `constraint=false`)
+			return
+		}
+		id := fmt.Sprintf("n%d", len(seen))
+		seen[n] = id
+		g.node(id, fmt.Sprintf(`shape=record, label="{%s|<next>}"`, record(fmt.Sprint(n.Value()))))
+		g.edge(prev, id, "")
+		prev = id + ":next:c"
+	}
+}
+
+// treeDOT draws one oval per tree node with an edge to each child. A
+// missing child is drawn as a point, which keeps siblings in position.
+func treeDOT(g *graph, root Tree) {
+	var next int
+	var walk func(t Tree) string
+	walk = func(t Tree) string {
+		id := fmt.Sprintf("t%d", next)
+		next++
+		if isNil(t) {
+			g.node(id, `shape=point`)
+			return id
+		}
+		g.node(id, fmt.Sprintf(`label=%q`, fmt.Sprint(t.Value())))
+		for _, c := range t.Children() {
+			g.edge(id, walk(c), "")
+		}
+		return id
+	}
+	walk(root)
+}
@@ -0,0 +1,94 @@
+// Package viz draws the containers in this repository as ASCII diagrams
+// and as Graphviz DOT graphs.
+//
+// The containers live in their own main packages with unexported fields, so
+// viz does not know about them directly. Instead each container exposes a
+// few read-only accessor methods that satisfy one of the interfaces below.
+package viz
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Slots is a container backed by a fixed-capacity slice, such as an ArrayList.
+// Indexes from Len() up to Cap()-1 are allocated but unused.
+type Slots interface {
+	Len() int
+	Cap() int
+	At(i int) any
+}
+
+// Stacked is a slice-backed stack. Top returns the index of the top element,
+// or -1 when the stack is empty.
+type Stacked interface {
+	Slots
+	Top() int
+}
+
+// Linked is a node in a singly linked list. Next returns nil at the tail.
+type Linked interface {
+	Value() any
+	Next() Linked
+}
+
+// Tree is a node in a tree. Children are positional: a nil entry (or a
+// typed nil pointer) stands for a missing child and is drawn as a
+// placeholder, so a binary tree's lone left and right children look
+// different. A leaf returns no children at all.
+type Tree interface {
+	Value() any
+	Children() []Tree
+}
+
+// ASCII renders c as a plain-text diagram. Containers that do not satisfy
+// any of the interfaces in this package are printed with fmt.
+func ASCII(c any) string {
+	if isNil(c) {
+		return "nil\n"
+	}
+	switch v := c.(type) {
+	case Stacked:
+		return stackASCII(v)
+	case Slots:
+		return slotsASCII(v)
+	case Linked:
+		return listASCII(v)
+	case Tree:
+		return treeASCII(v)
+	}
+	return fmt.Sprintln(c)
+}
+
+// DOT renders c as a Graphviz digraph called name.
+// The output can be piped straight into `dot -Tsvg`.
+func DOT(c any, name string) string {
+	g := newGraph(name)
+	if isNil(c) {
+		g.node("nil", `shape=point`)
+		return g.String()
+	}
+	switch v := c.(type) {
+	case Stacked:
+		stackDOT(g, v)
+	case Slots:
+		slotsDOT(g, v)
+	case Linked:
+		listDOT(g, v)
+	case Tree:
+		treeDOT(g, v)
+	default:
+		g.node("value", fmt.Sprintf(`shape=box, label=%q`, fmt.Sprint(c)))
+	}
+	return g.String()
+}
+
+// isNil reports whether v is nil or a typed nil pointer, which is how an
+// empty linked list or tree usually arrives here.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -0,0 +1,138 @@
+package viz
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// The fakes below mirror the repository's containers, which live in main
+// packages and cannot be imported.
+
+type slots struct {
+	vals []int
+	cap  int
+}
+
+func (s *slots) Len() int     { return len(s.vals) }
+func (s *slots) Cap() int     { return s.cap }
+func (s *slots) At(i int) any { return s.vals[i] }
+
+type stack struct{ slots }
+
+func (s *stack) Top() int { return len(s.vals) - 1 }
+
+type node struct {
+	val  int
+	next *node
+}
+
+func (n *node) Value() any { return n.val }
+
+func (n *node) Next() Linked {
+	if n.next == nil {
+		return nil
+	}
+	return n.next
+}
+
+type tree struct {
+	val  int
+	kids []Tree
+}
+
+func (t *tree) Value() any       { return t.val }
+func (t *tree) Children() []Tree { return t.kids }
+
+// list links vals into a list, returning its head.
+func list(vals ...int) *node {
+	var head *node
+	for i := len(vals) - 1; i >= 0; i-- {
+		head = &node{vals[i], head}
+	}
+	return head
+}
+
+// bst builds the tree from inserting 50, 30, 40, 70, 60: both 30 and 70
+// have a single child, on opposite sides.
+func bst() *tree {
+	leaf := func(v int) *tree { return &tree{val: v} }
+	return &tree{val: 50, kids: []Tree{
+		&tree{val: 30, kids: []Tree{nil, leaf(40)}},
+		&tree{val: 70, kids: []Tree{leaf(60), (*tree)(nil)}},
+	}}
+}
+
+func cycle() *node {
+	head := list(1, 2, 3)
+	head.next.next.next = head.next
+	return head
+}
+
+var cases = []struct {
+	name string
+	c    any
+}{
+	{"nil", nil},
+	{"slots_empty", &slots{cap: 0}},
+	{"slots", &slots{vals: []int{10, 20, 30}, cap: 4}},
+	{"stack_empty", &stack{slots{cap: 2}}},
+	{"stack", &stack{slots{vals: []int{1, 200}, cap: 3}}},
+	{"list_nil", (*node)(nil)},
+	{"list", list(1, 2, 3)},
+	{"list_cycle", cycle()},
+	{"tree_leaf", &tree{val: 7}},
+	{"tree", bst()},
+	{"other", 42},
+}
+
+// golden compares got with testdata/name, or rewrites it with -update.
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch\n got:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestASCII(t *testing.T) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			golden(t, tc.name+".txt", ASCII(tc.c))
+		})
+	}
+}
+
+func TestDOT(t *testing.T) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			golden(t, tc.name+".dot", DOT(tc.c, tc.name))
+		})
+	}
+}
+
+// TestLoneChildren checks that the side of a lone child shows in the
+// drawing, which is the point of positional children.
+func TestLoneChildren(t *testing.T) {
+	left := &tree{val: 1, kids: []Tree{&tree{val: 0}, nil}}
+	right := &tree{val: 1, kids: []Tree{nil, &tree{val: 0}}}
+	if ASCII(left) == ASCII(right) {
+		t.Errorf("lone left and right children draw the same:\n%s", ASCII(left))
+	}
+	if DOT(left, "") == DOT(right, "") {
+		t.Errorf("lone left and right children give the same DOT graph")
+	}
+}
@@ -0,0 +1,123 @@
+// Package collections provides generic helpers for working with slices,
+// iterators, sets and a few small container types.
+//
+// The slice functions in this file are eager and return new slices. Each of
+// the lazy ones has a counterpart in seq.go that works on iter.Seq values, so
+// pipelines can be built without allocating intermediate slices.
+package collections
+
+// Map returns a new slice holding f applied to every element of s.
+func Map[T, U any](s []T, f func(T) U) []U {
+	out := make([]U, 0, len(s))
+	for _, v := range s {
+		out = append(out, f(v))
+	}
+	return out
+}
+
+// Filter returns the elements of s for which keep returns true, in order.
+func Filter[T any](s []T, keep func(T) bool) []T {
+	var out []T
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s into a single value, starting from init and calling f with
+// the running result and each element in turn.
+func Reduce[T, A any](s []T, init A, f func(A, T) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// GroupBy groups the elements of s by the key returned from key. Elements
+// keep their original relative order inside each group.
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Partition splits s into the elements that satisfy pred and those that don't.
+func Partition[T any](s []T, pred func(T) bool) (yes, no []T) {
+	for _, v := range s {
+		if pred(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// Chunk splits s into consecutive slices of length size. The last chunk is
+// shorter if len(s) is not a multiple of size. The chunks share memory with s.
+// Chunk panics if size is less than 1.
+func Chunk[T any](s []T, size int) [][]T {
+	if size < 1 {
+		panic("collections: chunk size must be at least 1")
+	}
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for i := 0; i < len(s); i += size {
+		end := min(i+size, len(s))
+		chunks = append(chunks, s[i:end:end])
+	}
+	return chunks
+}
+
+// Zip pairs up the elements of a and b by index. The result is as long as
+// the shorter of the two.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := min(len(a), len(b))
+	out := make([]Pair[A, B], n)
+	for i := range n {
+		out[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return out
+}
+
+// Unzip is the inverse of Zip.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	a := make([]A, len(pairs))
+	b := make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i], b[i] = p.First, p.Second
+	}
+	return a, b
+}
+
+// Distinct returns the elements of s with duplicates removed, keeping the
+// first occurrence of each.
+func Distinct[T comparable](s []T) []T {
+	seen := make(map[T]struct{}, len(s))
+	var out []T
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Flatten concatenates the slices in s into a single slice.
+func Flatten[T any](s [][]T) []T {
+	n := 0
+	for _, inner := range s {
+		n += len(inner)
+	}
+	out := make([]T, 0, n)
+	for _, inner := range s {
+		out = append(out, inner...)
+	}
+	return out
+}
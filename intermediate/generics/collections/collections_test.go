@@ -0,0 +1,103 @@
+package collections
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func isEven(n int) bool { return n%2 == 0 }
+
+func TestMapFilterReduce(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	if got, want := Map(s, strconv.Itoa), []string{"1", "2", "3", "4", "5"}; !slices.Equal(got, want) {
+		t.Errorf("Map = %q, want %q", got, want)
+	}
+	if got := Map([]int(nil), strconv.Itoa); got == nil || len(got) != 0 {
+		t.Errorf("Map(nil) = %#v, want an empty slice", got)
+	}
+	if got, want := Filter(s, isEven), []int{2, 4}; !slices.Equal(got, want) {
+		t.Errorf("Filter = %v, want %v", got, want)
+	}
+	if got := Filter(s, func(int) bool { return false }); got != nil {
+		t.Errorf("Filter keeping nothing = %v, want nil", got)
+	}
+	if got := Reduce(s, "", func(acc string, n int) string { return acc + strconv.Itoa(n) }); got != "12345" {
+		t.Errorf("Reduce = %q, want %q", got, "12345")
+	}
+	if got := Reduce([]int(nil), 7, func(a, b int) int { return a + b }); got != 7 {
+		t.Errorf("Reduce(nil) = %d, want the initial value", got)
+	}
+}
+
+func TestGroupByPartition(t *testing.T) {
+	words := []string{"go", "rust", "c", "zig", "java", "d"}
+	got := GroupBy(words, func(w string) int { return len(w) })
+	want := map[int][]string{1: {"c", "d"}, 2: {"go"}, 3: {"zig"}, 4: {"rust", "java"}}
+	if !maps.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("GroupBy = %v, want %v", got, want)
+	}
+
+	yes, no := Partition([]int{1, 2, 3, 4, 5, 6}, isEven)
+	if !slices.Equal(yes, []int{2, 4, 6}) || !slices.Equal(no, []int{1, 3, 5}) {
+		t.Errorf("Partition = %v, %v", yes, no)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		s    []int
+		size int
+		want [][]int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{[]int{1, 2}, 5, [][]int{{1, 2}}},
+		{nil, 3, [][]int{}},
+	}
+	for _, tt := range tests {
+		if got := Chunk(tt.s, tt.size); !slices.EqualFunc(got, tt.want, slices.Equal) {
+			t.Errorf("Chunk(%v, %d) = %v, want %v", tt.s, tt.size, got, tt.want)
+		}
+	}
+
+	// Chunks share memory with s but cannot grow into the next chunk
+	s := []int{1, 2, 3, 4}
+	chunks := Chunk(s, 2)
+	chunks[0][0] = 10
+	_ = append(chunks[0], 99)
+	if s[0] != 10 || s[2] != 3 {
+		t.Errorf("after writing through chunks, s = %v", s)
+	}
+}
+
+func TestChunkPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunk with size 0 did not panic")
+		}
+	}()
+	Chunk([]int{1}, 0)
+}
+
+func TestZipUnzip(t *testing.T) {
+	pairs := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	if !slices.Equal(pairs, want) {
+		t.Errorf("Zip = %v, want %v", pairs, want)
+	}
+	names, nums := Unzip(pairs)
+	if !slices.Equal(names, []string{"a", "b"}) || !slices.Equal(nums, []int{1, 2}) {
+		t.Errorf("Unzip = %v, %v", names, nums)
+	}
+}
+
+func TestDistinctFlatten(t *testing.T) {
+	if got, want := Distinct([]int{3, 1, 3, 2, 1}), []int{3, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("Distinct = %v, want %v", got, want)
+	}
+	if got, want := Flatten([][]int{{1, 2}, nil, {3}}), []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("Flatten = %v, want %v", got, want)
+	}
+}
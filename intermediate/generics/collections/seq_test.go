@@ -0,0 +1,112 @@
+package collections
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// counted yields 1..n and records how many values were pulled, so tests
+// can check that the lazy functions stop early.
+func counted(n int, pulled *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 1; i <= n; i++ {
+			*pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// take collects at most n values of seq.
+func take[T any](seq iter.Seq[T], n int) []T {
+	var out []T
+	for v := range seq {
+		if len(out) == n {
+			break
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestSeqMatchesSlices(t *testing.T) {
+	s := []int{1, 2, 3, 2, 4, 5, 1}
+	square := func(n int) int { return n * n }
+	if got, want := slices.Collect(MapSeq(slices.Values(s), square)), Map(s, square); !slices.Equal(got, want) {
+		t.Errorf("MapSeq = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(FilterSeq(slices.Values(s), isEven)), Filter(s, isEven); !slices.Equal(got, want) {
+		t.Errorf("FilterSeq = %v, want %v", got, want)
+	}
+	add := func(a, b int) int { return a + b }
+	if got, want := ReduceSeq(slices.Values(s), 0, add), Reduce(s, 0, add); got != want {
+		t.Errorf("ReduceSeq = %d, want %d", got, want)
+	}
+	if got, want := slices.Collect(ChunkSeq(slices.Values(s), 3)), Chunk(s, 3); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("ChunkSeq = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(DistinctSeq(slices.Values(s))), Distinct(s); !slices.Equal(got, want) {
+		t.Errorf("DistinctSeq = %v, want %v", got, want)
+	}
+	nested := [][]int{{1}, nil, {2, 3}}
+	seqs := MapSeq(slices.Values(nested), func(s []int) iter.Seq[int] { return slices.Values(s) })
+	if got, want := slices.Collect(FlattenSeq(seqs)), Flatten(nested); !slices.Equal(got, want) {
+		t.Errorf("FlattenSeq = %v, want %v", got, want)
+	}
+}
+
+func TestSeqStopsEarly(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(seq iter.Seq[int])
+		want int // values pulled from the source
+	}{
+		{"MapSeq", func(seq iter.Seq[int]) { take(MapSeq(seq, func(n int) int { return n }), 2) }, 3},
+		{"FilterSeq", func(seq iter.Seq[int]) { take(FilterSeq(seq, isEven), 2) }, 6},
+		{"ChunkSeq", func(seq iter.Seq[int]) { take(ChunkSeq(seq, 3), 1) }, 6},
+		{"DistinctSeq", func(seq iter.Seq[int]) { take(DistinctSeq(seq), 1) }, 2},
+		{"FlattenSeq", func(seq iter.Seq[int]) {
+			take(FlattenSeq(func(yield func(iter.Seq[int]) bool) { yield(seq) }), 4)
+		}, 5},
+	}
+	for _, tt := range tests {
+		pulled := 0
+		tt.run(counted(1000, &pulled))
+		if pulled != tt.want {
+			t.Errorf("%s pulled %d values, want %d", tt.name, pulled, tt.want)
+		}
+	}
+}
+
+func TestChunkSeqFreshSlices(t *testing.T) {
+	chunks := slices.Collect(ChunkSeq(slices.Values([]int{1, 2, 3, 4, 5}), 2))
+	chunks[0][0] = 10
+	if chunks[1][0] != 3 {
+		t.Errorf("chunks share memory: %v", chunks)
+	}
+	if got := slices.Collect(ChunkSeq(slices.Values([]int(nil)), 2)); len(got) != 0 {
+		t.Errorf("ChunkSeq of nothing = %v", got)
+	}
+}
+
+func TestZipSeq(t *testing.T) {
+	var names []string
+	var nums []int
+	for name, n := range ZipSeq(slices.Values([]string{"a", "b", "c"}), slices.Values([]int{1, 2})) {
+		names, nums = append(names, name), append(nums, n)
+	}
+	if !slices.Equal(names, []string{"a", "b"}) || !slices.Equal(nums, []int{1, 2}) {
+		t.Errorf("ZipSeq = %v, %v", names, nums)
+	}
+
+	// Stopping early releases the pulled sequence
+	pulled := 0
+	for range ZipSeq(slices.Values([]int{1, 2, 3}), counted(1000, &pulled)) {
+		break
+	}
+	if pulled != 1 {
+		t.Errorf("ZipSeq pulled %d values after one pair, want 1", pulled)
+	}
+}
@@ -0,0 +1,110 @@
+package collections
+
+import (
+	"iter"
+	"maps"
+)
+
+// Set is an unordered collection of distinct values. The zero value is not
+// ready to use; create sets with NewSet or CollectSet.
+type Set[T comparable] map[T]struct{}
+
+// NewSet returns a set holding items.
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	for _, v := range items {
+		s.Add(v)
+	}
+	return s
+}
+
+// CollectSet returns a set holding every value produced by seq.
+func CollectSet[T comparable](seq iter.Seq[T]) Set[T] {
+	s := make(Set[T])
+	for v := range seq {
+		s.Add(v)
+	}
+	return s
+}
+
+// Add inserts v into the set.
+func (s Set[T]) Add(v T) {
+	s[v] = struct{}{}
+}
+
+// Remove deletes v from the set if present.
+func (s Set[T]) Remove(v T) {
+	delete(s, v)
+}
+
+// Contains reports whether v is in the set.
+func (s Set[T]) Contains(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Len returns the number of values in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// All returns an iterator over the values in the set, in no particular order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// Union returns a new set with the values that are in s or other.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	out := make(Set[T], len(s)+len(other))
+	for v := range s {
+		out.Add(v)
+	}
+	for v := range other {
+		out.Add(v)
+	}
+	return out
+}
+
+// Intersection returns a new set with the values that are in both s and other.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	out := make(Set[T])
+	for v := range small {
+		if large.Contains(v) {
+			out.Add(v)
+		}
+	}
+	return out
+}
+
+// Difference returns a new set with the values of s that are not in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	out := make(Set[T])
+	for v := range s {
+		if !other.Contains(v) {
+			out.Add(v)
+		}
+	}
+	return out
+}
+
+// SubsetOf reports whether every value of s is also in other.
+func (s Set[T]) SubsetOf(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for v := range s {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether s and other hold exactly the same values.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.SubsetOf(other)
+}
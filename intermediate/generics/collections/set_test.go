@@ -0,0 +1,77 @@
+package collections
+
+import (
+	"slices"
+	"testing"
+)
+
+// sorted returns the values of s in increasing order.
+func sorted(s Set[int]) []int {
+	return slices.Sorted(s.All())
+}
+
+func TestSetBasics(t *testing.T) {
+	s := NewSet(3, 1, 3, 2)
+	if s.Len() != 3 || !slices.Equal(sorted(s), []int{1, 2, 3}) {
+		t.Errorf("NewSet = %v", sorted(s))
+	}
+	s.Add(4)
+	s.Remove(1)
+	s.Remove(99)
+	if s.Contains(1) || !s.Contains(4) || s.Len() != 3 {
+		t.Errorf("after Add and Remove = %v", sorted(s))
+	}
+	if got := CollectSet(slices.Values([]int{5, 5, 6})); !got.Equal(NewSet(5, 6)) {
+		t.Errorf("CollectSet = %v", sorted(got))
+	}
+	if NewSet[int]().Len() != 0 {
+		t.Error("NewSet() is not empty")
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a, b := NewSet(1, 2, 3, 4), NewSet(3, 4, 5)
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"intersection", a.Intersection(b), []int{3, 4}},
+		{"intersection reversed", b.Intersection(a), []int{3, 4}},
+		{"difference", a.Difference(b), []int{1, 2}},
+		{"difference reversed", b.Difference(a), []int{5}},
+		{"with empty", a.Intersection(NewSet[int]()), nil},
+	}
+	for _, tt := range tests {
+		if got := sorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	// The operations return new sets
+	if !slices.Equal(sorted(a), []int{1, 2, 3, 4}) || !slices.Equal(sorted(b), []int{3, 4, 5}) {
+		t.Errorf("operands changed: %v, %v", sorted(a), sorted(b))
+	}
+}
+
+func TestSetCompare(t *testing.T) {
+	tests := []struct {
+		a, b          Set[int]
+		subset, equal bool
+	}{
+		{NewSet(1, 2), NewSet(1, 2, 3), true, false},
+		{NewSet(1, 2, 3), NewSet(1, 2), false, false},
+		{NewSet(1, 4), NewSet(1, 2, 3), false, false},
+		{NewSet(2, 1), NewSet(1, 2), true, true},
+		{NewSet[int](), NewSet(1), true, false},
+		{NewSet[int](), NewSet[int](), true, true},
+	}
+	for _, tt := range tests {
+		if got := tt.a.SubsetOf(tt.b); got != tt.subset {
+			t.Errorf("%v.SubsetOf(%v) = %v", sorted(tt.a), sorted(tt.b), got)
+		}
+		if got := tt.a.Equal(tt.b); got != tt.equal {
+			t.Errorf("%v.Equal(%v) = %v", sorted(tt.a), sorted(tt.b), got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package collections
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// String formats the pair as (first, second).
+func (p Pair[A, B]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// Optional holds either a value or nothing. The zero value is empty.
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, ok: true}
+}
+
+// None returns an empty Optional.
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Get returns the value and whether one is present.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// IsSome reports whether a value is present.
+func (o Optional[T]) IsSome() bool {
+	return o.ok
+}
+
+// OrElse returns the value if present and fallback otherwise.
+func (o Optional[T]) OrElse(fallback T) T {
+	if o.ok {
+		return o.value
+	}
+	return fallback
+}
+
+// String formats the optional as Some(v) or None.
+func (o Optional[T]) String() string {
+	if o.ok {
+		return fmt.Sprintf("Some(%v)", o.value)
+	}
+	return "None"
+}
+
+// MapOptional applies f to the value of o, if any.
+func MapOptional[T, U any](o Optional[T], f func(T) U) Optional[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(f(o.value))
+}
+
+// Result holds either a value or the error that prevented producing it.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result holding v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a failed Result. A nil err is replaced with a generic error so
+// that the Result is never mistaken for a success.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		err = errors.New("collections: Err called with nil error")
+	}
+	return Result[T]{err: err}
+}
+
+// Try calls f and captures its outcome as a Result.
+func Try[T any](f func() (T, error)) Result[T] {
+	v, err := f()
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(v)
+}
+
+// Unwrap returns the value and error in the usual Go form.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.value, r.err
+}
+
+// IsOk reports whether the Result holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// OrElse returns the value on success and fallback on failure.
+func (r Result[T]) OrElse(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+	return r.value
+}
+
+// String formats the result as Ok(v) or Err(message).
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// MapResult applies f to the value of r, passing failures through unchanged.
+func MapResult[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
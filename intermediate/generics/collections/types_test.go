@@ -0,0 +1,77 @@
+package collections
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestPair(t *testing.T) {
+	if got := fmt.Sprint(Pair[string, int]{"a", 1}); got != "(a, 1)" {
+		t.Errorf("Pair = %s", got)
+	}
+}
+
+func TestOptional(t *testing.T) {
+	some, none := Some(42), None[int]()
+	if v, ok := some.Get(); !ok || v != 42 || !some.IsSome() {
+		t.Errorf("Some(42).Get() = %d, %v", v, ok)
+	}
+	if v, ok := none.Get(); ok || v != 0 || none.IsSome() {
+		t.Errorf("None().Get() = %d, %v", v, ok)
+	}
+	var zero Optional[int]
+	if zero != none {
+		t.Error("the zero Optional is not None")
+	}
+	if some.OrElse(7) != 42 || none.OrElse(7) != 7 {
+		t.Errorf("OrElse = %d, %d", some.OrElse(7), none.OrElse(7))
+	}
+	if s := fmt.Sprint(some, " ", none); s != "Some(42) None" {
+		t.Errorf("String = %q", s)
+	}
+	if got := MapOptional(some, strconv.Itoa); got != Some("42") {
+		t.Errorf("MapOptional(Some) = %v", got)
+	}
+	called := false
+	if got := MapOptional(none, func(int) string { called = true; return "" }); got.IsSome() || called {
+		t.Errorf("MapOptional(None) = %v, f called: %v", got, called)
+	}
+}
+
+func TestResult(t *testing.T) {
+	errBoom := errors.New("boom")
+	ok, bad := Ok(42), Err[int](errBoom)
+	if v, err := ok.Unwrap(); v != 42 || err != nil || !ok.IsOk() {
+		t.Errorf("Ok(42).Unwrap() = %d, %v", v, err)
+	}
+	if v, err := bad.Unwrap(); v != 0 || err != errBoom || bad.IsOk() {
+		t.Errorf("Err(boom).Unwrap() = %d, %v", v, err)
+	}
+	if ok.OrElse(7) != 42 || bad.OrElse(7) != 7 {
+		t.Errorf("OrElse = %d, %d", ok.OrElse(7), bad.OrElse(7))
+	}
+	if s := fmt.Sprint(ok, " ", bad); s != "Ok(42) Err(boom)" {
+		t.Errorf("String = %q", s)
+	}
+
+	// Err(nil) must still be a failure
+	if r := Err[int](nil); r.IsOk() {
+		t.Error("Err(nil) is Ok")
+	}
+
+	if r := Try(func() (int, error) { return strconv.Atoi("12") }); r.OrElse(0) != 12 {
+		t.Errorf("Try(Atoi(12)) = %v", r)
+	}
+	if r := Try(func() (int, error) { return strconv.Atoi("x") }); r.IsOk() {
+		t.Errorf("Try(Atoi(x)) = %v", r)
+	}
+
+	if got := MapResult(ok, strconv.Itoa); got.OrElse("") != "42" {
+		t.Errorf("MapResult(Ok) = %v", got)
+	}
+	if _, err := MapResult(bad, strconv.Itoa).Unwrap(); err != errBoom {
+		t.Errorf("MapResult(Err) error = %v, want boom", err)
+	}
+}
@@ -1,6 +1,11 @@
 package main	
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+
+	"intermediate/generics/collections"
+)
 
 func swap[T any](a, b T) (T, T) {
 	return b, a
@@ -16,4 +21,20 @@ func main() {
 	a1, b1 := "Raju", "Shyam"
 	a1, b1 = swap(a1, b1)
 	fmt.Println(a1, b1)
+
+	// The collections package builds on the same idea: one function, many types
+	nums := []int{1, 2, 3, 4, 5, 6}
+	squares := collections.Map(nums, func(n int) int { return n * n })
+	evens, odds := collections.Partition(nums, func(n int) bool { return n%2 == 0 })
+	total := collections.Reduce(nums, 0, func(acc, n int) int { return acc + n })
+	fmt.Println(squares, evens, odds, total)
+
+	// Lazy pipelines over iterators only do the work that is asked for
+	words := slices.Values([]string{"go", "is", "fun", "go", "go"})
+	lengths := collections.MapSeq(collections.DistinctSeq(words), func(w string) int { return len(w) })
+	fmt.Println(slices.Collect(lengths))
+
+	x := collections.NewSet(1, 2, 3)
+	y := collections.NewSet(2, 3, 4)
+	fmt.Println(slices.Sorted(x.Intersection(y).All()), slices.Sorted(x.Difference(y).All()))
 }
\ No newline at end of file
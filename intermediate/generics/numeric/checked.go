@@ -0,0 +1,48 @@
+package numeric
+
+// These are this module's only checked integer operations; other packages
+// here, such as the recursion lesson, call them rather than repeating the
+// overflow tests. The basics module has its own in arithmetic_operator/checked,
+// since the two modules do not import each other.
+
+// isSigned reports whether T is a signed integer type.
+func isSigned[T Integer]() bool {
+	var zero T
+	return ^zero < 0
+}
+
+// AddChecked returns a + b, or ErrOverflow if the result does not fit in T.
+func AddChecked[T Integer](a, b T) (T, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+// MulChecked returns a * b, or ErrOverflow if the result does not fit in T.
+func MulChecked[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	// Dividing back recovers a unless the product wrapped. The one case
+	// division misses is MinInt * -1, because MinInt / -1 wraps to MinInt too.
+	if product/b != a || (isSigned[T]() && b == ^T(0) && a < 0 && -a == a) {
+		return 0, ErrOverflow
+	}
+	return product, nil
+}
+
+// SumChecked returns the sum of nums, or ErrOverflow as soon as a partial
+// sum does not fit in T.
+func SumChecked[T Integer](nums ...T) (T, error) {
+	var total T
+	for _, n := range nums {
+		var err error
+		if total, err = AddChecked(total, n); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,70 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAddChecked(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+		err        error
+	}{
+		{1, 2, 3, nil},
+		{math.MaxInt64, 0, math.MaxInt64, nil},
+		{math.MaxInt64, 1, 0, ErrOverflow},
+		{math.MinInt64, -1, 0, ErrOverflow},
+		{math.MinInt64, math.MaxInt64, -1, nil},
+	}
+	for _, tt := range tests {
+		got, err := AddChecked(tt.a, tt.b)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("AddChecked(%d, %d) = %d, %v; want %d, %v", tt.a, tt.b, got, err, tt.want, tt.err)
+		}
+	}
+	if _, err := AddChecked[uint8](200, 56); !errors.Is(err, ErrOverflow) {
+		t.Errorf("AddChecked[uint8](200, 56) error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestMulChecked(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+		err        error
+	}{
+		{6, 7, 42, nil},
+		{0, math.MinInt64, 0, nil},
+		{-1, math.MaxInt64, -math.MaxInt64, nil},
+		{math.MinInt64, -1, 0, ErrOverflow},
+		{-1, math.MinInt64, 0, ErrOverflow},
+		{math.MinInt64, 1, math.MinInt64, nil},
+		{1 << 32, 1 << 31, 0, ErrOverflow},
+		{1 << 31, 1 << 31, 1 << 62, nil},
+	}
+	for _, tt := range tests {
+		got, err := MulChecked(tt.a, tt.b)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("MulChecked(%d, %d) = %d, %v; want %d, %v", tt.a, tt.b, got, err, tt.want, tt.err)
+		}
+	}
+	if got, err := MulChecked[uint8](15, 17); got != 255 || err != nil {
+		t.Errorf("MulChecked[uint8](15, 17) = %d, %v", got, err)
+	}
+	if _, err := MulChecked[uint8](16, 16); !errors.Is(err, ErrOverflow) {
+		t.Errorf("MulChecked[uint8](16, 16) error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestSumChecked(t *testing.T) {
+	if got, err := SumChecked[int8](); got != 0 || err != nil {
+		t.Errorf("SumChecked() = %d, %v", got, err)
+	}
+	if got, err := SumChecked[int8](100, 27, -50); got != 77 || err != nil {
+		t.Errorf("SumChecked(100, 27, -50) = %d, %v", got, err)
+	}
+	// The partial sum 100+28 overflows even though the total would fit
+	if _, err := SumChecked[int8](100, 28, -50); !errors.Is(err, ErrOverflow) {
+		t.Errorf("SumChecked(100, 28, -50) error = %v, want ErrOverflow", err)
+	}
+}
@@ -0,0 +1,147 @@
+// Package numeric provides arithmetic and statistics functions that work on
+// any built-in number type, instead of being written once per type.
+package numeric
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+// Signed is satisfied by every signed integer type.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is satisfied by every unsigned integer type.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is satisfied by every integer type.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is satisfied by every floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is satisfied by every integer and floating-point type.
+type Number interface {
+	Integer | Float
+}
+
+var (
+	// ErrEmpty is returned by functions that need at least one value.
+	ErrEmpty = errors.New("numeric: no values")
+
+	// ErrOverflow is returned when an integer result does not fit its type.
+	ErrOverflow = errors.New("numeric: integer overflow")
+)
+
+// Sum returns the sum of nums, or 0 if there are none. Like the + operator,
+// integer sums wrap on overflow; use AddChecked to detect that.
+func Sum[T Number](nums ...T) T {
+	var total T
+	for _, n := range nums {
+		total += n
+	}
+	return total
+}
+
+// Product returns the product of nums, or 1 if there are none. Integer
+// products wrap on overflow; use MulChecked to detect that.
+func Product[T Number](nums ...T) T {
+	product := T(1)
+	for _, n := range nums {
+		product *= n
+	}
+	return product
+}
+
+// Min returns the smallest of nums.
+func Min[T Number](nums ...T) (T, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	return slices.Min(nums), nil
+}
+
+// Max returns the largest of nums.
+func Max[T Number](nums ...T) (T, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	return slices.Max(nums), nil
+}
+
+// Mean returns the arithmetic mean of nums. The sum is accumulated in
+// float64, so integer inputs cannot overflow.
+func Mean[T Number](nums ...T) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	var total float64
+	for _, n := range nums {
+		total += float64(n)
+	}
+	return total / float64(len(nums)), nil
+}
+
+// Variance returns the population variance of nums.
+func Variance[T Number](nums ...T) (float64, error) {
+	mean, err := Mean(nums...)
+	if err != nil {
+		return 0, err
+	}
+	var squares float64
+	for _, n := range nums {
+		d := float64(n) - mean
+		squares += d * d
+	}
+	return squares / float64(len(nums)), nil
+}
+
+// StdDev returns the population standard deviation of nums.
+func StdDev[T Number](nums ...T) (float64, error) {
+	variance, err := Variance(nums...)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(variance), nil
+}
+
+// Median returns the middle value of nums, or the mean of the two middle
+// values when there is an even number of them. nums is not modified.
+func Median[T Number](nums ...T) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[mid]), nil
+	}
+	return (float64(sorted[mid-1]) + float64(sorted[mid])) / 2, nil
+}
+
+// Clamp limits v to the range [lo, hi]. If lo > hi the two are swapped.
+func Clamp[T Number](v, lo, hi T) T {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return min(max(v, lo), hi)
+}
+
+// Abs returns the absolute value of v. For signed integers the most
+// negative value has no positive counterpart and is returned unchanged,
+// exactly as -v would.
+func Abs[T Number](v T) T {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -1,19 +1,22 @@
 package main
 
 import (
+    "encoding/json"
+    "errors"
+    "flag"
     "fmt"
+    "io"
     "math"
+
+    "intermediate/interfaces/shapes"
 )
 
-// geometry interface defines methods that any geometric shape must implement
+// geometry interface defines methods that any geometric shape must implement.
+// The methods are exported, so types from other packages, such as
+// shapes.Rectangle, can satisfy it too.
 type geometry interface {
-    area() float64
-    perimeter() float64
-}
-
-// rectangle struct represents a rectangle with width and height
-type rectangle struct {
-    width, height float64
+    Area() float64
+    Perimeter() float64
 }
 
 // circle struct represents a circle with a radius
@@ -21,39 +24,94 @@ type circle struct {
     radius float64
 }
 
-// area calculates the area of a rectangle (implements geometry)
-func (r rectangle) area() float64 {
-    return r.height * r.width
-}
-
-// area calculates the area of a circle (implements geometry)
-func (c circle) area() float64 {
+// Area calculates the area of a circle (implements geometry)
+func (c circle) Area() float64 {
     return math.Pi * c.radius * c.radius
 }
 
-// perimeter calculates the perimeter of a rectangle (implements geometry)
-func (r rectangle) perimeter() float64 {
-    return 2 * (r.width + r.height)
-}
-
-// perimeter calculates the perimeter of a circle (implements geometry)
-func (c circle) perimeter() float64 {
+// Perimeter calculates the perimeter of a circle (implements geometry)
+func (c circle) Perimeter() float64 {
     return 2 * math.Pi * c.radius
 }
 
 // measure takes any geometry type and prints its details, area, and perimeter
 func measure(g geometry) {
     fmt.Println(g)
-    fmt.Println("Area:", g.area())
-    fmt.Println("Perimeter:", g.perimeter())
+    fmt.Println("Area:", g.Area())
+    fmt.Println("Perimeter:", g.Perimeter())
 }
 
 func main() {
-    // Create a rectangle and a circle
-    r := rectangle{width: 3, height: 4}
+    svgPath := flag.String("svg", "", "write the shapes as an SVG document to this file")
+    flag.Parse()
+
+    // Create a rectangle and a circle. The rectangle comes from the shapes
+    // package; the circle is defined in this file
+    r := shapes.Rectangle{Width: 3, Height: 4}
     c := circle{radius: 3}
 
     // Pass both to measure, demonstrating interface usage
     measure(r)
     measure(c)
+
+    // The shapes package exports the same idea with more shapes and methods
+    square, _ := shapes.NewSquare(shapes.Point{X: 0, Y: 0}, 2)
+    triangle, _ := shapes.NewTriangle(shapes.Point{X: 0, Y: 0}, shapes.Point{X: 4, Y: 0}, shapes.Point{X: 0, Y: 3})
+    hexagon, _ := shapes.NewRegularPolygon(shapes.Point{X: 0, Y: 0}, 6, 1)
+    rect, _ := shapes.NewRectangle(shapes.Point{X: 0, Y: 0}, 3, 4)
+    for _, s := range []shapes.Shape{square, triangle, hexagon, rect} {
+        fmt.Printf("%T area=%.2f perimeter=%.2f bounds=%v contains(1,1)=%t\n",
+            s, s.Area(), s.Perimeter(), s.Bounds(), s.Contains(shapes.Point{X: 1, Y: 1}))
+    }
+
+    // Optional capabilities are discovered with a type assertion
+    for _, s := range []shapes.Shape{triangle, rect} {
+        if sc, ok := s.(shapes.Scalable); ok {
+            if err := sc.Scale(2); err != nil {
+                fmt.Println(err)
+            }
+        }
+        if r, ok := s.(shapes.Resettable); ok {
+            r.Reset()
+        }
+        fmt.Printf("%T area after scaling (and resetting if supported): %.2f\n", s, s.Area())
+    }
+
+    // A ShapeSet holds different shapes together and remembers their types in JSON
+    var set shapes.ShapeSet
+    if err := json.Unmarshal([]byte(`[{"type":"circle","radius":3},{"type":"rectangle","width":3,"height":4}]`), &set); err != nil {
+        fmt.Println(err)
+    }
+    set.SortByArea()
+    for _, s := range set.All() {
+        fmt.Printf("%T area=%.2f\n", s, s.Area())
+    }
+    fmt.Printf("total area=%.2f perimeter=%.2f\n", set.TotalArea(), set.TotalPerimeter())
+
+    // Draw the shapes side by side, labelled with their area and perimeter
+    canvas := shapes.NewCanvas()
+    canvas.Columns = 3
+    var errs []error
+    for _, s := range set.All() {
+        errs = append(errs, canvas.Add(s, ""))
+    }
+    errs = append(errs, canvas.Add(square, ""), canvas.Add(triangle, ""), canvas.Add(hexagon, "hexagon"))
+    if err := errors.Join(errs...); err != nil {
+        fmt.Println(err)
+    }
+    // The file is only written when asked for with -svg
+    if *svgPath != "" {
+        if err := canvas.WriteFile(*svgPath); err != nil {
+            fmt.Println(err)
+        } else {
+            fmt.Println("wrote", *svgPath)
+        }
+    } else if n, err := canvas.WriteTo(io.Discard); err == nil {
+        fmt.Printf("SVG document of %d bytes; run with -svg FILE to save it\n", n)
+    }
+
+    // Invalid dimensions are rejected with an error instead of a silly shape
+    if _, err := shapes.NewCircle(shapes.Point{}, -1); err != nil {
+        fmt.Println(err)
+    }
 }
\ No newline at end of file
@@ -0,0 +1,54 @@
+package shapes
+
+// Optional capabilities. Not every shape supports every operation, so tools
+// should discover them with a type assertion:
+//
+//	if s, ok := shape.(shapes.Scalable); ok {
+//		err = s.Scale(2)
+//	}
+//
+// The methods change the shape in place, so only pointers satisfy them.
+
+// Scalable is implemented by shapes that can be resized by a positive factor.
+type Scalable interface {
+	Scale(factor float64) error
+}
+
+// Translatable is implemented by shapes that can be moved.
+type Translatable interface {
+	Translate(dx, dy float64)
+}
+
+// Resettable is implemented by shapes that can be collapsed to zero size.
+type Resettable interface {
+	Reset()
+}
+
+// Compile-time checks that each shape has the capabilities it documents.
+var (
+	_ Shape = Circle{}
+	_ Shape = Ellipse{}
+	_ Shape = Square{}
+	_ Shape = Rectangle{}
+	_ Shape = Triangle{}
+	_ Shape = Polygon{}
+	_ Shape = RegularPolygon{}
+
+	_ Scalable = (*Circle)(nil)
+	_ Scalable = (*Ellipse)(nil)
+	_ Scalable = (*Square)(nil)
+	_ Scalable = (*Rectangle)(nil)
+	_ Scalable = (*Triangle)(nil)
+	_ Scalable = (*Polygon)(nil)
+	_ Scalable = (*RegularPolygon)(nil)
+
+	_ Translatable = (*Circle)(nil)
+	_ Translatable = (*Ellipse)(nil)
+	_ Translatable = (*Square)(nil)
+	_ Translatable = (*Rectangle)(nil)
+	_ Translatable = (*Triangle)(nil)
+	_ Translatable = (*Polygon)(nil)
+	_ Translatable = (*RegularPolygon)(nil)
+
+	_ Resettable = (*Rectangle)(nil)
+)
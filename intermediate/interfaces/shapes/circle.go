@@ -0,0 +1,69 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+)
+
+// Circle is the set of points within Radius of Center.
+type Circle struct {
+	Center Point   `json:"center"`
+	Radius float64 `json:"radius"`
+}
+
+// NewCircle returns a validated circle.
+func NewCircle(center Point, radius float64) (*Circle, error) {
+	c := &Circle{Center: center, Radius: radius}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate reports whether c is a real circle.
+func (c Circle) Validate() error {
+	if err := checkPoints(c.Center); err != nil {
+		return fmt.Errorf("circle: %w", err)
+	}
+	if err := checkDimension("radius", c.Radius); err != nil {
+		return fmt.Errorf("circle: %w", err)
+	}
+	return nil
+}
+
+// Area returns πr².
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Perimeter returns the circumference 2πr.
+func (c Circle) Perimeter() float64 {
+	return 2 * math.Pi * c.Radius
+}
+
+// Bounds returns the square that just encloses the circle.
+func (c Circle) Bounds() Box {
+	return Box{
+		Min: c.Center.translate(-c.Radius, -c.Radius),
+		Max: c.Center.translate(c.Radius, c.Radius),
+	}
+}
+
+// Contains reports whether p lies inside or on the circle.
+func (c Circle) Contains(p Point) bool {
+	return c.Center.Distance(p) <= c.Radius
+}
+
+// Translate moves the circle by dx, dy.
+func (c *Circle) Translate(dx, dy float64) {
+	c.Center = c.Center.translate(dx, dy)
+}
+
+// Scale multiplies the radius by factor, keeping the center fixed.
+func (c *Circle) Scale(factor float64) error {
+	if err := checkFactor(factor); err != nil {
+		return err
+	}
+	c.Radius *= factor
+	return nil
+}
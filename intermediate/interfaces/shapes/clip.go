@@ -0,0 +1,130 @@
+package shapes
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ClipToBox returns the part of p that lies inside b, using the
+// Sutherland–Hodgman algorithm: the polygon is cut by each edge of the box
+// in turn. The result has no vertices if p lies entirely outside b. Concave
+// polygons that leave and re-enter the box come back as a single polygon
+// joined along the box's edge.
+func ClipToBox(p Polygon, b Box) Polygon {
+	edges := []struct {
+		inside func(Point) bool
+		cut    func(from, to Point) Point
+	}{
+		{func(q Point) bool { return q.X >= b.Min.X }, func(s, e Point) Point { return atX(s, e, b.Min.X) }},
+		{func(q Point) bool { return q.X <= b.Max.X }, func(s, e Point) Point { return atX(s, e, b.Max.X) }},
+		{func(q Point) bool { return q.Y >= b.Min.Y }, func(s, e Point) Point { return atY(s, e, b.Min.Y) }},
+		{func(q Point) bool { return q.Y <= b.Max.Y }, func(s, e Point) Point { return atY(s, e, b.Max.Y) }},
+	}
+
+	out := slices.Clone(p.Vertices)
+	for _, edge := range edges {
+		in := out
+		out = nil
+		for i, end := range in {
+			start := in[(i+len(in)-1)%len(in)]
+			switch {
+			case edge.inside(end) && edge.inside(start):
+				out = append(out, end)
+			case edge.inside(end):
+				out = append(out, edge.cut(start, end), end)
+			case edge.inside(start):
+				out = append(out, edge.cut(start, end))
+			}
+		}
+	}
+	return Polygon{Vertices: out}
+}
+
+// atX returns the point where the line through s and e crosses x.
+func atX(s, e Point, x float64) Point {
+	return Point{x, s.Y + (e.Y-s.Y)*(x-s.X)/(e.X-s.X)}
+}
+
+// atY returns the point where the line through s and e crosses y.
+func atY(s, e Point, y float64) Point {
+	return Point{s.X + (e.X-s.X)*(y-s.Y)/(e.Y-s.Y), y}
+}
+
+// UnionArea returns the area covered by at least one of polygons, which may
+// be concave and may overlap in any way, but must each be simple.
+//
+// It walks every edge and works out which fraction of it lies on the outside
+// of the union, i.e. is not covered by any other polygon. Summing the
+// shoelace terms of just those fractions gives the area of the union's
+// boundary. Edges shared by several polygons are counted once.
+func UnionArea(polygons ...Polygon) float64 {
+	ccw := make([][]Point, len(polygons))
+	for i, p := range polygons {
+		ccw[i] = slices.Clone(p.Vertices)
+		if p.signedArea() < 0 {
+			slices.Reverse(ccw[i])
+		}
+	}
+
+	type event struct {
+		t     float64 // position along the edge, 0 at a and 1 at b
+		delta int     // +1 where another polygon starts covering the edge, -1 where it stops
+	}
+
+	var total float64
+	for i, poly := range ccw {
+		for v, a := range poly {
+			b := poly[(v+1)%len(poly)]
+			ab := b.Sub(a)
+			events := []event{{0, 0}, {1, 0}}
+			for j, other := range ccw {
+				if i == j {
+					continue
+				}
+				for u, c := range other {
+					d := other[(u+1)%len(other)]
+					sc, sd := orientation(a, b, c), orientation(a, b, d)
+					switch {
+					case sc != sd:
+						// cd crosses the line through ab. Entering from the
+						// right starts covering ab, leaving to the right stops.
+						if min(sc, sd) < 0 {
+							ca, cb := cross(c, d, a), cross(c, d, b)
+							events = append(events, event{ca / (ca - cb), cmp.Compare(sc, sd)})
+						}
+					case sc == 0 && j < i && ab.Dot(d.Sub(c)) > 0:
+						// A shared edge running the same way: let the
+						// earlier polygon own it
+						events = append(events, event{param(c.Sub(a), ab), 1}, event{param(d.Sub(a), ab), -1})
+					}
+				}
+			}
+
+			slices.SortFunc(events, func(x, y event) int {
+				return cmp.Or(cmp.Compare(x.t, y.t), cmp.Compare(x.delta, y.delta))
+			})
+			var uncovered float64
+			covered := events[0].delta
+			for k := 1; k < len(events); k++ {
+				if covered == 0 {
+					uncovered += clamp01(events[k].t) - clamp01(events[k-1].t)
+				}
+				covered += events[k].delta
+			}
+			total += a.Sub(Point{}).Cross(b.Sub(Point{})) * uncovered
+		}
+	}
+	return total / 2
+}
+
+// param returns the multiple of dir that v is, for v parallel to dir.
+func param(v, dir Vector) float64 {
+	if dir.X != 0 {
+		return v.X / dir.X
+	}
+	return v.Y / dir.Y
+}
+
+func clamp01(t float64) float64 {
+	return min(max(t, 0), 1)
+}
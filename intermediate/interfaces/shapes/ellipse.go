@@ -0,0 +1,80 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+)
+
+// Ellipse is an axis-aligned ellipse with semi-axes RadiusX and RadiusY.
+type Ellipse struct {
+	Center  Point   `json:"center"`
+	RadiusX float64 `json:"radius_x"`
+	RadiusY float64 `json:"radius_y"`
+}
+
+// NewEllipse returns a validated ellipse.
+func NewEllipse(center Point, radiusX, radiusY float64) (*Ellipse, error) {
+	e := &Ellipse{Center: center, RadiusX: radiusX, RadiusY: radiusY}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Validate reports whether e is a real ellipse.
+func (e Ellipse) Validate() error {
+	if err := checkPoints(e.Center); err != nil {
+		return fmt.Errorf("ellipse: %w", err)
+	}
+	if err := checkDimension("x radius", e.RadiusX); err != nil {
+		return fmt.Errorf("ellipse: %w", err)
+	}
+	if err := checkDimension("y radius", e.RadiusY); err != nil {
+		return fmt.Errorf("ellipse: %w", err)
+	}
+	return nil
+}
+
+// Area returns πab.
+func (e Ellipse) Area() float64 {
+	return math.Pi * e.RadiusX * e.RadiusY
+}
+
+// Perimeter has no closed form, so this uses Ramanujan's second
+// approximation, which is exact for circles and within 0.04% even for very
+// flat ellipses.
+func (e Ellipse) Perimeter() float64 {
+	a, b := e.RadiusX, e.RadiusY
+	h := (a - b) * (a - b) / ((a + b) * (a + b))
+	return math.Pi * (a + b) * (1 + 3*h/(10+math.Sqrt(4-3*h)))
+}
+
+// Bounds returns the box that just encloses the ellipse.
+func (e Ellipse) Bounds() Box {
+	return Box{
+		Min: e.Center.translate(-e.RadiusX, -e.RadiusY),
+		Max: e.Center.translate(e.RadiusX, e.RadiusY),
+	}
+}
+
+// Contains reports whether p lies inside or on the ellipse.
+func (e Ellipse) Contains(p Point) bool {
+	dx := (p.X - e.Center.X) / e.RadiusX
+	dy := (p.Y - e.Center.Y) / e.RadiusY
+	return dx*dx+dy*dy <= 1
+}
+
+// Translate moves the ellipse by dx, dy.
+func (e *Ellipse) Translate(dx, dy float64) {
+	e.Center = e.Center.translate(dx, dy)
+}
+
+// Scale multiplies both radii by factor, keeping the center fixed.
+func (e *Ellipse) Scale(factor float64) error {
+	if err := checkFactor(factor); err != nil {
+		return err
+	}
+	e.RadiusX *= factor
+	e.RadiusY *= factor
+	return nil
+}
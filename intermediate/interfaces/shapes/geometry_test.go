@@ -0,0 +1,179 @@
+package shapes
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func pt(x, y float64) Point { return Point{x, y} }
+
+func TestSegmentContains(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Segment
+		p    Point
+		want bool
+	}{
+		{"midpoint", Segment{pt(0, 0), pt(2, 2)}, pt(1, 1), true},
+		{"endpoint", Segment{pt(0, 0), pt(2, 2)}, pt(2, 2), true},
+		{"beyond the end", Segment{pt(0, 0), pt(2, 2)}, pt(3, 3), false},
+		{"off the line", Segment{pt(0, 0), pt(2, 2)}, pt(1, 1.1), false},
+		// Collinear within Epsilon must also pass the range check
+		{"vertical, x off by a hair", Segment{pt(0, 0), pt(0, 1)}, pt(1e-12, 0.5), true},
+		{"horizontal, y off by a hair", Segment{pt(0, 0), pt(1, 0)}, pt(0.5, -1e-12), true},
+		{"just past the end", Segment{pt(0, 0), pt(0, 1)}, pt(0, 1+1e-12), true},
+		{"clearly past the end", Segment{pt(0, 0), pt(0, 1)}, pt(0, 1.001), false},
+		{"degenerate, same point", Segment{pt(1, 1), pt(1, 1)}, pt(1, 1), true},
+		{"degenerate, other point", Segment{pt(1, 1), pt(1, 1)}, pt(1, 2), false},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Contains(tt.p); got != tt.want {
+			t.Errorf("%s: %v.Contains(%v) = %t, want %t", tt.name, tt.s, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestSegmentIntersection(t *testing.T) {
+	tests := []struct {
+		name   string
+		s, u   Segment
+		want   Segment
+		wantOK bool
+	}{
+		{"crossing", Segment{pt(0, 0), pt(2, 2)}, Segment{pt(0, 2), pt(2, 0)}, Segment{pt(1, 1), pt(1, 1)}, true},
+		{"touching at an end", Segment{pt(0, 0), pt(1, 1)}, Segment{pt(1, 1), pt(2, 0)}, Segment{pt(1, 1), pt(1, 1)}, true},
+		{"T junction", Segment{pt(0, 0), pt(2, 0)}, Segment{pt(1, 0), pt(1, 5)}, Segment{pt(1, 0), pt(1, 0)}, true},
+		{"parallel", Segment{pt(0, 0), pt(2, 0)}, Segment{pt(0, 1), pt(2, 1)}, Segment{}, false},
+		{"apart", Segment{pt(0, 0), pt(1, 1)}, Segment{pt(3, 0), pt(2, 1)}, Segment{}, false},
+		{"collinear overlap", Segment{pt(0, 0), pt(3, 0)}, Segment{pt(2, 0), pt(5, 0)}, Segment{pt(2, 0), pt(3, 0)}, true},
+		{"collinear, reversed", Segment{pt(0, 0), pt(3, 0)}, Segment{pt(5, 0), pt(2, 0)}, Segment{pt(2, 0), pt(3, 0)}, true},
+		{"collinear, contained", Segment{pt(0, 0), pt(4, 4)}, Segment{pt(1, 1), pt(2, 2)}, Segment{pt(1, 1), pt(2, 2)}, true},
+		{"collinear, touching", Segment{pt(0, 0), pt(1, 0)}, Segment{pt(1, 0), pt(2, 0)}, Segment{pt(1, 0), pt(1, 0)}, true},
+		{"collinear, disjoint", Segment{pt(0, 0), pt(1, 0)}, Segment{pt(2, 0), pt(3, 0)}, Segment{}, false},
+		{"degenerate on segment", Segment{pt(1, 0), pt(1, 0)}, Segment{pt(0, 0), pt(2, 0)}, Segment{pt(1, 0), pt(1, 0)}, true},
+		{"degenerate off segment", Segment{pt(1, 1), pt(1, 1)}, Segment{pt(0, 0), pt(2, 0)}, Segment{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.s.Intersection(tt.u)
+		if ok != tt.wantOK || ok && !(got.A.Equal(tt.want.A) && got.B.Equal(tt.want.B)) {
+			t.Errorf("%s: Intersection = %v, %t; want %v, %t", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+		if tt.s.Intersects(tt.u) != tt.u.Intersects(tt.s) {
+			t.Errorf("%s: Intersects is not symmetric", tt.name)
+		}
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		want   []Point
+	}{
+		{"empty", nil, nil},
+		{"single", []Point{pt(1, 1)}, []Point{pt(1, 1)}},
+		{"duplicates", []Point{pt(1, 1), pt(1, 1), pt(1, 1)}, []Point{pt(1, 1)}},
+		{"collinear", []Point{pt(2, 2), pt(0, 0), pt(1, 1), pt(3, 3)}, []Point{pt(0, 0), pt(3, 3)}},
+		{"square with inner and edge points",
+			[]Point{pt(0, 0), pt(2, 0), pt(2, 2), pt(0, 2), pt(1, 1), pt(1, 0), pt(2, 1)},
+			[]Point{pt(0, 0), pt(2, 0), pt(2, 2), pt(0, 2)}},
+	}
+	for _, tt := range tests {
+		got := ConvexHull(tt.points)
+		if !slices.EqualFunc(got, tt.want, Point.Equal) {
+			t.Errorf("%s: ConvexHull = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClosestPair(t *testing.T) {
+	if _, _, ok := ClosestPair([]Point{pt(0, 0)}); ok {
+		t.Error("ClosestPair of one point reported ok")
+	}
+	points := []Point{pt(0, 0), pt(10, 10), pt(5, 5), pt(5.5, 5.2), pt(-3, 8), pt(9, 0)}
+	a, b, ok := ClosestPair(points)
+	if !ok || a.Distance(b) != pt(5, 5).Distance(pt(5.5, 5.2)) {
+		t.Errorf("ClosestPair = %v, %v, %t", a, b, ok)
+	}
+	// Coincident points are at distance zero
+	if a, b, _ := ClosestPair([]Point{pt(1, 1), pt(4, 4), pt(1, 1)}); a.Distance(b) != 0 {
+		t.Errorf("ClosestPair with duplicates = %v, %v", a, b)
+	}
+}
+
+func TestDegenerateShapes(t *testing.T) {
+	if _, err := NewTriangle(pt(0, 0), pt(1, 1), pt(2, 2)); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("collinear triangle: error = %v, want ErrDegenerate", err)
+	}
+	if _, err := NewTriangle(pt(0, 0), pt(1e6, 1e6), pt(2e6, 2e6+1e-6)); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("nearly collinear large triangle: error = %v, want ErrDegenerate", err)
+	}
+	if _, err := NewTriangle(pt(0, 0), pt(1e-6, 0), pt(0, 1e-6)); err != nil {
+		t.Errorf("small but proper triangle: %v", err)
+	}
+	if _, err := NewPolygon(pt(0, 0), pt(1, 0)); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("two-vertex polygon: error = %v, want ErrDegenerate", err)
+	}
+	if _, err := NewPolygon(pt(0, 0), pt(1, 0), pt(2, 0), pt(3, 0)); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("flat polygon: error = %v, want ErrDegenerate", err)
+	}
+	if _, err := NewCircle(pt(0, 0), math.NaN()); !errors.Is(err, ErrNotFinite) {
+		t.Errorf("NaN radius: error = %v, want ErrNotFinite", err)
+	}
+	// Zero values fail validation but do not panic
+	for _, r := range []RegularPolygon{{}, {Sides: -1}} {
+		if err := r.Validate(); !errors.Is(err, ErrDegenerate) {
+			t.Errorf("%d-sided regular polygon: error = %v, want ErrDegenerate", r.Sides, err)
+		}
+		if b := r.Bounds(); b != (Box{}) {
+			t.Errorf("%d-sided regular polygon: Bounds = %v, want an empty Box", r.Sides, b)
+		}
+		if r.Contains(pt(0, 0)) {
+			t.Errorf("%d-sided regular polygon contains the origin", r.Sides)
+		}
+	}
+}
+
+func TestPolygonContainsEdges(t *testing.T) {
+	sq, _ := NewPolygon(pt(0, 0), pt(2, 0), pt(2, 2), pt(0, 2))
+	for _, p := range []Point{pt(1, 1), pt(0, 0), pt(1, 0), pt(2, 1), pt(1e-12, 1), pt(2+1e-12, 1)} {
+		if !sq.Contains(p) {
+			t.Errorf("square does not contain %v", p)
+		}
+	}
+	for _, p := range []Point{pt(3, 1), pt(-0.001, 1), pt(1, 2.001)} {
+		if sq.Contains(p) {
+			t.Errorf("square contains %v", p)
+		}
+	}
+}
+
+func TestClipAndUnion(t *testing.T) {
+	sq, _ := NewPolygon(pt(0, 0), pt(2, 0), pt(2, 2), pt(0, 2))
+	clipped := ClipToBox(*sq, Box{pt(1, 1), pt(3, 3)})
+	if got := clipped.Area(); math.Abs(got-1) > 1e-9 {
+		t.Errorf("clipped area = %v, want 1", got)
+	}
+	if got := ClipToBox(*sq, Box{pt(5, 5), pt(6, 6)}); len(got.Vertices) != 0 {
+		t.Errorf("clip outside the box = %v, want no vertices", got)
+	}
+
+	shifted, _ := NewPolygon(pt(1, 0), pt(3, 0), pt(3, 2), pt(1, 2))
+	touching, _ := NewPolygon(pt(2, 0), pt(4, 0), pt(4, 2), pt(2, 2))
+	tests := []struct {
+		name string
+		ps   []Polygon
+		want float64
+	}{
+		{"overlapping", []Polygon{*sq, *shifted}, 6},
+		{"identical", []Polygon{*sq, *sq}, 4},
+		{"sharing an edge", []Polygon{*sq, *touching}, 8},
+	}
+	for _, tt := range tests {
+		if got := UnionArea(tt.ps...); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: UnionArea = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package shapes
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// ConvexHull returns the corners of the smallest convex polygon containing
+// points, counter-clockwise starting from the lowest-leftmost point. Points
+// on the hull's edges are left out. It uses Andrew's monotone chain
+// algorithm, which runs in O(n log n).
+//
+// Fewer than three distinct points, or points that are all collinear, give a
+// degenerate hull: the distinct endpoints of the line, or the single point.
+func ConvexHull(points []Point) []Point {
+	sorted := slices.Clone(points)
+	slices.SortFunc(sorted, func(a, b Point) int {
+		return cmp.Or(cmp.Compare(a.X, b.X), cmp.Compare(a.Y, b.Y))
+	})
+	sorted = slices.CompactFunc(sorted, Point.Equal)
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	// Build the lower hull left to right, then the upper hull right to left,
+	// popping any point that would make a clockwise or straight turn
+	hull := make([]Point, 0, 2*len(sorted))
+	for _, pass := range [2]func(yield func(int, Point) bool){slices.All(sorted), slices.Backward(sorted)} {
+		start := len(hull)
+		for _, p := range pass {
+			for len(hull) >= start+2 && orientation(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		// The last point of each pass is the first point of the next one
+		hull = hull[:len(hull)-1]
+	}
+	if len(hull) < 3 {
+		// All collinear: keep just the two extremes
+		return []Point{sorted[0], sorted[len(sorted)-1]}
+	}
+	return hull
+}
+
+// ClosestPair returns the two points of points that are nearest each other.
+// ok is false when there are fewer than two points. It uses the divide and
+// conquer algorithm, which runs in O(n log n).
+func ClosestPair(points []Point) (a, b Point, ok bool) {
+	if len(points) < 2 {
+		return Point{}, Point{}, false
+	}
+	byX := slices.Clone(points)
+	slices.SortFunc(byX, func(p, q Point) int { return cmp.Compare(p.X, q.X) })
+	pair := closestPair(byX)
+	return pair[0], pair[1], true
+}
+
+// closestPair solves ClosestPair for at least two points sorted by X. On
+// return the points are sorted by Y, which lets the caller merge halves in
+// linear time.
+func closestPair(points []Point) [2]Point {
+	if len(points) <= 3 {
+		best := [2]Point{points[0], points[1]}
+		for i := range points {
+			for j := i + 1; j < len(points); j++ {
+				if points[i].Distance(points[j]) < best[0].Distance(best[1]) {
+					best = [2]Point{points[i], points[j]}
+				}
+			}
+		}
+		slices.SortFunc(points, func(p, q Point) int { return cmp.Compare(p.Y, q.Y) })
+		return best
+	}
+
+	mid := len(points) / 2
+	midX := points[mid].X
+	best := closestPair(points[:mid])
+	if right := closestPair(points[mid:]); right[0].Distance(right[1]) < best[0].Distance(best[1]) {
+		best = right
+	}
+
+	// Merge the two halves back into Y order
+	merged := make([]Point, 0, len(points))
+	left, right := slices.Clone(points[:mid]), points[mid:]
+	for len(left) > 0 && len(right) > 0 {
+		if left[0].Y <= right[0].Y {
+			merged, left = append(merged, left[0]), left[1:]
+		} else {
+			merged, right = append(merged, right[0]), right[1:]
+		}
+	}
+	merged = append(append(merged, left...), right...)
+	copy(points, merged)
+
+	// Only points within d of the dividing line can beat the best so far,
+	// and each needs checking against just the next few in Y order
+	d := best[0].Distance(best[1])
+	var strip []Point
+	for _, p := range points {
+		if math.Abs(p.X-midX) < d {
+			strip = append(strip, p)
+		}
+	}
+	for i := range strip {
+		for j := i + 1; j < len(strip) && strip[j].Y-strip[i].Y < d; j++ {
+			if dist := strip[i].Distance(strip[j]); dist < d {
+				best, d = [2]Point{strip[i], strip[j]}, dist
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,221 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+)
+
+// Polygon is a simple polygon given by its vertices in order, in either
+// winding direction. The last vertex connects back to the first.
+// Self-intersecting vertex lists are not detected.
+type Polygon struct {
+	Vertices []Point `json:"vertices"`
+}
+
+// NewPolygon returns a validated polygon. The vertices are copied.
+func NewPolygon(vertices ...Point) (*Polygon, error) {
+	p := &Polygon{Vertices: append([]Point(nil), vertices...)}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Validate reports whether p has at least three vertices and a non-zero area.
+func (p Polygon) Validate() error {
+	if len(p.Vertices) < 3 {
+		return fmt.Errorf("polygon: %d vertices: %w", len(p.Vertices), ErrDegenerate)
+	}
+	if err := checkPoints(p.Vertices...); err != nil {
+		return fmt.Errorf("polygon: %w", err)
+	}
+	if perimeter := p.Perimeter(); p.Area() <= Epsilon*perimeter*perimeter {
+		return fmt.Errorf("polygon: vertices are collinear: %w", ErrDegenerate)
+	}
+	return nil
+}
+
+// signedArea uses the shoelace formula. It is positive for counter-clockwise
+// vertices and negative for clockwise ones.
+func (p Polygon) signedArea() float64 {
+	var sum float64
+	for i, a := range p.Vertices {
+		b := p.Vertices[(i+1)%len(p.Vertices)]
+		sum += a.X*b.Y - b.X*a.Y
+	}
+	return sum / 2
+}
+
+// Area returns the enclosed area, computed with the shoelace formula.
+func (p Polygon) Area() float64 {
+	return math.Abs(p.signedArea())
+}
+
+// Perimeter returns the total length of the edges.
+func (p Polygon) Perimeter() float64 {
+	var total float64
+	for i, a := range p.Vertices {
+		total += a.Distance(p.Vertices[(i+1)%len(p.Vertices)])
+	}
+	return total
+}
+
+// Bounds returns the box that just encloses the polygon.
+func (p Polygon) Bounds() Box {
+	if len(p.Vertices) == 0 {
+		return Box{}
+	}
+	return boundsOf(p.Vertices)
+}
+
+// Contains reports whether q lies inside or on the edge of the polygon.
+// It counts how many edges a ray from q to the right crosses: an odd count
+// means q is inside.
+func (p Polygon) Contains(q Point) bool {
+	inside := false
+	for i, a := range p.Vertices {
+		b := p.Vertices[(i+1)%len(p.Vertices)]
+		if onSegment(a, b, q) {
+			return true
+		}
+		if (a.Y > q.Y) != (b.Y > q.Y) {
+			x := a.X + (q.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if q.X < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// onSegment reports whether q lies on the segment from a to b. The range
+// check uses the same tolerance as the collinearity check, so a point that
+// counts as on the line is not then rejected for being a hair outside the
+// segment's bounding box.
+func onSegment(a, b, q Point) bool {
+	return orientation(a, b, q) == 0 && between(q.X, a.X, b.X) && between(q.Y, a.Y, b.Y)
+}
+
+// between reports whether v lies between a and b, within Epsilon.
+func between(v, a, b float64) bool {
+	lo, hi := math.Min(a, b), math.Max(a, b)
+	return (v >= lo || nearlyEqual(v, lo)) && (v <= hi || nearlyEqual(v, hi))
+}
+
+// Centroid returns the center of mass of the polygon's area.
+func (p Polygon) Centroid() Point {
+	var cx, cy float64
+	for i, a := range p.Vertices {
+		b := p.Vertices[(i+1)%len(p.Vertices)]
+		f := a.X*b.Y - b.X*a.Y
+		cx += (a.X + b.X) * f
+		cy += (a.Y + b.Y) * f
+	}
+	area6 := 6 * p.signedArea()
+	return Point{cx / area6, cy / area6}
+}
+
+// Translate moves every vertex by dx, dy.
+func (p *Polygon) Translate(dx, dy float64) {
+	for i := range p.Vertices {
+		p.Vertices[i] = p.Vertices[i].translate(dx, dy)
+	}
+}
+
+// Scale resizes the polygon by factor about its centroid.
+func (p *Polygon) Scale(factor float64) error {
+	if err := checkFactor(factor); err != nil {
+		return err
+	}
+	c := p.Centroid()
+	for i := range p.Vertices {
+		p.Vertices[i] = p.Vertices[i].scaleAbout(c, factor)
+	}
+	return nil
+}
+
+// RegularPolygon has Sides equal sides, with every vertex at distance Radius
+// from Center. Rotation, in radians, is the angle of the first vertex.
+type RegularPolygon struct {
+	Center   Point   `json:"center"`
+	Sides    int     `json:"sides"`
+	Radius   float64 `json:"radius"`
+	Rotation float64 `json:"rotation,omitempty"`
+}
+
+// NewRegularPolygon returns a validated regular polygon.
+func NewRegularPolygon(center Point, sides int, radius float64) (*RegularPolygon, error) {
+	r := &RegularPolygon{Center: center, Sides: sides, Radius: radius}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Validate reports whether r has at least three sides and a positive radius.
+func (r RegularPolygon) Validate() error {
+	if r.Sides < 3 {
+		return fmt.Errorf("regular polygon: %d sides: %w", r.Sides, ErrDegenerate)
+	}
+	if err := checkPoints(r.Center); err != nil {
+		return fmt.Errorf("regular polygon: %w", err)
+	}
+	if err := checkDimension("radius", r.Radius); err != nil {
+		return fmt.Errorf("regular polygon: %w", err)
+	}
+	return nil
+}
+
+// Vertices returns the corners of r in counter-clockwise order, or nil if r
+// has no sides, as the zero value does.
+func (r RegularPolygon) Vertices() []Point {
+	if r.Sides <= 0 {
+		return nil
+	}
+	vertices := make([]Point, r.Sides)
+	for i := range vertices {
+		angle := r.Rotation + 2*math.Pi*float64(i)/float64(r.Sides)
+		vertices[i] = Point{
+			r.Center.X + r.Radius*math.Cos(angle),
+			r.Center.Y + r.Radius*math.Sin(angle),
+		}
+	}
+	return vertices
+}
+
+// Area returns ½ n r² sin(2π/n).
+func (r RegularPolygon) Area() float64 {
+	n := float64(r.Sides)
+	return n * r.Radius * r.Radius * math.Sin(2*math.Pi/n) / 2
+}
+
+// Perimeter returns 2 n r sin(π/n).
+func (r RegularPolygon) Perimeter() float64 {
+	n := float64(r.Sides)
+	return 2 * n * r.Radius * math.Sin(math.Pi/n)
+}
+
+// Bounds returns the box that just encloses the polygon, or an empty Box if
+// it has no sides.
+func (r RegularPolygon) Bounds() Box {
+	return Polygon{Vertices: r.Vertices()}.Bounds()
+}
+
+// Contains reports whether p lies inside or on the edge of the polygon.
+func (r RegularPolygon) Contains(p Point) bool {
+	return Polygon{Vertices: r.Vertices()}.Contains(p)
+}
+
+// Translate moves the polygon by dx, dy.
+func (r *RegularPolygon) Translate(dx, dy float64) {
+	r.Center = r.Center.translate(dx, dy)
+}
+
+// Scale multiplies the radius by factor, keeping the center fixed.
+func (r *RegularPolygon) Scale(factor float64) error {
+	if err := checkFactor(factor); err != nil {
+		return err
+	}
+	r.Radius *= factor
+	return nil
+}
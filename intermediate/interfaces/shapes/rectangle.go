@@ -0,0 +1,78 @@
+package shapes
+
+import "fmt"
+
+// Rectangle is an axis-aligned rectangle with its lower-left corner at Min.
+// It replaces both the rectangle of the interfaces lesson and the Rectangle
+// of the methods lesson: Width and Height play the part of Length and
+// Breadth, Scale generalizes DoubleDimensions, and Reset works as before.
+type Rectangle struct {
+	Min    Point   `json:"min"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// NewRectangle returns a validated rectangle.
+func NewRectangle(min Point, width, height float64) (*Rectangle, error) {
+	r := &Rectangle{Min: min, Width: width, Height: height}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Validate reports whether r is a real rectangle.
+func (r Rectangle) Validate() error {
+	if err := checkPoints(r.Min); err != nil {
+		return fmt.Errorf("rectangle: %w", err)
+	}
+	if err := checkDimension("width", r.Width); err != nil {
+		return fmt.Errorf("rectangle: %w", err)
+	}
+	if err := checkDimension("height", r.Height); err != nil {
+		return fmt.Errorf("rectangle: %w", err)
+	}
+	return nil
+}
+
+// Area returns width × height.
+func (r Rectangle) Area() float64 {
+	return r.Width * r.Height
+}
+
+// Perimeter returns 2 × (width + height).
+func (r Rectangle) Perimeter() float64 {
+	return 2 * (r.Width + r.Height)
+}
+
+// Bounds returns the rectangle itself.
+func (r Rectangle) Bounds() Box {
+	return Box{Min: r.Min, Max: r.Min.translate(r.Width, r.Height)}
+}
+
+// Contains reports whether p lies inside or on the rectangle.
+func (r Rectangle) Contains(p Point) bool {
+	return r.Bounds().Contains(p)
+}
+
+// Translate moves the rectangle by dx, dy.
+func (r *Rectangle) Translate(dx, dy float64) {
+	r.Min = r.Min.translate(dx, dy)
+}
+
+// Scale multiplies both dimensions by factor, keeping the lower-left corner
+// fixed. Scale(2) is the old DoubleDimensions.
+func (r *Rectangle) Scale(factor float64) error {
+	if err := checkFactor(factor); err != nil {
+		return err
+	}
+	r.Width *= factor
+	r.Height *= factor
+	return nil
+}
+
+// Reset sets both dimensions to zero, leaving the corner where it is.
+func (r *Rectangle) Reset() {
+	r.Width = 0
+	r.Height = 0
+}
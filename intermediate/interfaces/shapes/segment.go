@@ -0,0 +1,90 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+)
+
+// Segment is the straight line segment between A and B.
+type Segment struct {
+	A Point `json:"a"`
+	B Point `json:"b"`
+}
+
+func (s Segment) String() string {
+	return fmt.Sprintf("[%v - %v]", s.A, s.B)
+}
+
+// Length returns the distance from A to B.
+func (s Segment) Length() float64 {
+	return s.A.Distance(s.B)
+}
+
+// Contains reports whether p lies on the segment, within Epsilon.
+func (s Segment) Contains(p Point) bool {
+	if s.A.Equal(s.B) {
+		return s.A.Equal(p)
+	}
+	return onSegment(s.A, s.B, p)
+}
+
+// Intersects reports whether s and t share at least one point.
+func (s Segment) Intersects(t Segment) bool {
+	_, ok := s.Intersection(t)
+	return ok
+}
+
+// Intersection returns the part of the plane that s and t have in common.
+// Segments that cross or touch meet in a single point, returned as a
+// zero-length segment. Collinear segments may overlap along a stretch, which
+// is returned as a segment running in the direction of s.
+func (s Segment) Intersection(t Segment) (Segment, bool) {
+	d1, d2 := orientation(s.A, s.B, t.A), orientation(s.A, s.B, t.B)
+	d3, d4 := orientation(t.A, t.B, s.A), orientation(t.A, t.B, s.B)
+
+	// Proper crossing: each segment's endpoints are strictly on opposite
+	// sides of the other
+	if d1*d2 < 0 && d3*d4 < 0 {
+		r, q := s.B.Sub(s.A), t.B.Sub(t.A)
+		u := t.A.Sub(s.A).Cross(q) / r.Cross(q)
+		p := s.A.Add(r.Scale(u))
+		return Segment{p, p}, true
+	}
+
+	if d1 == 0 && d2 == 0 && d3 == 0 && d4 == 0 {
+		return collinearOverlap(s, t)
+	}
+
+	// Touching: an endpoint of one lies on the other
+	for _, c := range []struct {
+		seg Segment
+		p   Point
+	}{{s, t.A}, {s, t.B}, {t, s.A}, {t, s.B}} {
+		if c.seg.Contains(c.p) {
+			return Segment{c.p, c.p}, true
+		}
+	}
+	return Segment{}, false
+}
+
+// collinearOverlap intersects two segments known to lie on the same line by
+// projecting t onto s's direction.
+func collinearOverlap(s, t Segment) (Segment, bool) {
+	if s.A.Equal(s.B) {
+		if t.Contains(s.A) {
+			return s, true
+		}
+		return Segment{}, false
+	}
+	r := s.B.Sub(s.A)
+	rr := r.Dot(r)
+	t0 := t.A.Sub(s.A).Dot(r) / rr
+	t1 := t.B.Sub(s.A).Dot(r) / rr
+	lo := math.Max(0, math.Min(t0, t1))
+	hi := math.Min(1, math.Max(t0, t1))
+	if lo > hi && !nearlyEqual(lo, hi) {
+		return Segment{}, false
+	}
+	hi = math.Max(lo, hi)
+	return Segment{s.A.Add(r.Scale(lo)), s.A.Add(r.Scale(hi))}, true
+}
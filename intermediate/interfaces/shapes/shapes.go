@@ -0,0 +1,114 @@
+// Package shapes is the exported, fuller version of the geometry interface
+// from the interfaces lesson. Every shape knows its area and perimeter, its
+// bounding box and whether it contains a point, and can be moved and resized.
+//
+// Constructors validate their arguments; shapes built with composite
+// literals can be checked with Validate.
+package shapes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Shape is implemented by every shape in this package.
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+	Bounds() Box
+	Contains(p Point) bool
+}
+
+var (
+	// ErrNegativeDimension is returned for a negative length, radius or side.
+	ErrNegativeDimension = errors.New("shapes: negative dimension")
+
+	// ErrDegenerate is returned for shapes with no area, such as a zero
+	// radius, fewer than three vertices, or vertices that are all collinear.
+	ErrDegenerate = errors.New("shapes: degenerate shape")
+
+	// ErrNotFinite is returned when a coordinate or dimension is NaN or infinite.
+	ErrNotFinite = errors.New("shapes: value is not finite")
+
+	// ErrInvalidFactor is returned when scaling by a factor that is not positive.
+	ErrInvalidFactor = errors.New("shapes: scale factor must be positive")
+)
+
+// Point is a location in the plane.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%g, %g)", p.X, p.Y)
+}
+
+// Box is an axis-aligned rectangle given by its lower-left and upper-right corners.
+type Box struct {
+	Min, Max Point
+}
+
+// Width returns the horizontal extent of the box.
+func (b Box) Width() float64 {
+	return b.Max.X - b.Min.X
+}
+
+// Height returns the vertical extent of the box.
+func (b Box) Height() float64 {
+	return b.Max.Y - b.Min.Y
+}
+
+// Contains reports whether p lies inside or on the edge of the box.
+func (b Box) Contains(p Point) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+// Union returns the smallest box that covers both b and other.
+func (b Box) Union(other Box) Box {
+	return Box{
+		Min: Point{math.Min(b.Min.X, other.Min.X), math.Min(b.Min.Y, other.Min.Y)},
+		Max: Point{math.Max(b.Max.X, other.Max.X), math.Max(b.Max.Y, other.Max.Y)},
+	}
+}
+
+// boundsOf returns the bounding box of points, which must not be empty.
+func boundsOf(points []Point) Box {
+	b := Box{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		b = b.Union(Box{Min: p, Max: p})
+	}
+	return b
+}
+
+// checkDimension validates a length-like value named name.
+func checkDimension(name string, v float64) error {
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		return fmt.Errorf("%s %g: %w", name, v, ErrNotFinite)
+	case v < 0:
+		return fmt.Errorf("%s %g: %w", name, v, ErrNegativeDimension)
+	case v == 0:
+		return fmt.Errorf("%s is zero: %w", name, ErrDegenerate)
+	}
+	return nil
+}
+
+// checkPoints validates that every coordinate of points is finite.
+func checkPoints(points ...Point) error {
+	for _, p := range points {
+		if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+			return fmt.Errorf("point %v: %w", p, ErrNotFinite)
+		}
+	}
+	return nil
+}
+
+// checkFactor validates a scale factor.
+func checkFactor(factor float64) error {
+	if !(factor > 0) || math.IsInf(factor, 0) {
+		return fmt.Errorf("%g: %w", factor, ErrInvalidFactor)
+	}
+	return nil
+}
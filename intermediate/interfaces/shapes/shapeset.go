@@ -0,0 +1,209 @@
+package shapes
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// ErrUnknownType is returned when encoding a shape this package does not
+// know, or decoding a "type" it does not recognise.
+var ErrUnknownType = errors.New("shapes: unknown shape type")
+
+// ErrNilShape is returned when encoding a ShapeSet that holds a nil shape,
+// or when adding a nil shape to a Canvas.
+var ErrNilShape = errors.New("shapes: nil shape")
+
+// ShapeSet is an ordered collection of shapes of any kind.
+//
+// It encodes to JSON as an array of objects, each with a "type" field naming
+// the concrete shape, so that
+//
+//	[{"type":"circle","radius":3},{"type":"rectangle","width":3,"height":4}]
+//
+// decodes back into a *Circle and a *Rectangle.
+type ShapeSet struct {
+	shapes []Shape
+}
+
+// NewShapeSet returns a set holding shapes, in order.
+func NewShapeSet(shapes ...Shape) *ShapeSet {
+	return &ShapeSet{shapes: append([]Shape(nil), shapes...)}
+}
+
+// Add appends shapes to the set.
+func (s *ShapeSet) Add(shapes ...Shape) {
+	s.shapes = append(s.shapes, shapes...)
+}
+
+// Len returns the number of shapes in the set.
+func (s *ShapeSet) Len() int {
+	return len(s.shapes)
+}
+
+// At returns the shape at index i.
+func (s *ShapeSet) At(i int) Shape {
+	return s.shapes[i]
+}
+
+// All returns an iterator over the index and shape of every member.
+func (s *ShapeSet) All() iter.Seq2[int, Shape] {
+	return slices.All(s.shapes)
+}
+
+// SortBy sorts the set with cmp, keeping equal shapes in their current order.
+func (s *ShapeSet) SortBy(cmp func(a, b Shape) int) {
+	slices.SortStableFunc(s.shapes, cmp)
+}
+
+// SortByArea sorts the set from smallest to largest area.
+func (s *ShapeSet) SortByArea() {
+	s.SortBy(func(a, b Shape) int { return cmp.Compare(a.Area(), b.Area()) })
+}
+
+// SortByPerimeter sorts the set from shortest to longest perimeter.
+func (s *ShapeSet) SortByPerimeter() {
+	s.SortBy(func(a, b Shape) int { return cmp.Compare(a.Perimeter(), b.Perimeter()) })
+}
+
+// TotalArea returns the sum of the areas. Overlaps are counted twice.
+func (s *ShapeSet) TotalArea() float64 {
+	var total float64
+	for _, shape := range s.shapes {
+		total += shape.Area()
+	}
+	return total
+}
+
+// TotalPerimeter returns the sum of the perimeters.
+func (s *ShapeSet) TotalPerimeter() float64 {
+	var total float64
+	for _, shape := range s.shapes {
+		total += shape.Perimeter()
+	}
+	return total
+}
+
+// Bounds returns the smallest box covering every shape, or the zero Box if
+// the set is empty.
+func (s *ShapeSet) Bounds() Box {
+	if len(s.shapes) == 0 {
+		return Box{}
+	}
+	b := s.shapes[0].Bounds()
+	for _, shape := range s.shapes[1:] {
+		b = b.Union(shape.Bounds())
+	}
+	return b
+}
+
+// typeName returns the JSON "type" of shape.
+func typeName(shape Shape) (string, error) {
+	switch shape.(type) {
+	case Circle, *Circle:
+		return "circle", nil
+	case Ellipse, *Ellipse:
+		return "ellipse", nil
+	case Square, *Square:
+		return "square", nil
+	case Rectangle, *Rectangle:
+		return "rectangle", nil
+	case Triangle, *Triangle:
+		return "triangle", nil
+	case Polygon, *Polygon:
+		return "polygon", nil
+	case RegularPolygon, *RegularPolygon:
+		return "regular_polygon", nil
+	}
+	return "", fmt.Errorf("%T: %w", shape, ErrUnknownType)
+}
+
+// newShape returns a pointer to a zero shape for a JSON "type".
+func newShape(name string) (Shape, error) {
+	switch name {
+	case "circle":
+		return new(Circle), nil
+	case "ellipse":
+		return new(Ellipse), nil
+	case "square":
+		return new(Square), nil
+	case "rectangle":
+		return new(Rectangle), nil
+	case "triangle":
+		return new(Triangle), nil
+	case "polygon":
+		return new(Polygon), nil
+	case "regular_polygon":
+		return new(RegularPolygon), nil
+	}
+	return nil, fmt.Errorf("%q: %w", name, ErrUnknownType)
+}
+
+// MarshalJSON encodes the set as an array of objects tagged with "type".
+// It has a value receiver so that a ShapeSet encodes the same way whether
+// or not it is addressable. A nil shape is reported with its index.
+func (s ShapeSet) MarshalJSON() ([]byte, error) {
+	items := make([]json.RawMessage, len(s.shapes))
+	for i, shape := range s.shapes {
+		if shape == nil {
+			return nil, fmt.Errorf("shape %d: %w", i, ErrNilShape)
+		}
+		name, err := typeName(shape)
+		if err != nil {
+			return nil, fmt.Errorf("shape %d: %w", i, err)
+		}
+		fields, err := json.Marshal(shape)
+		if err != nil {
+			return nil, fmt.Errorf("shape %d: %w", i, err)
+		}
+		if bytes.Equal(fields, []byte("null")) {
+			// A typed nil pointer, such as (*Circle)(nil)
+			return nil, fmt.Errorf("shape %d: %w", i, ErrNilShape)
+		}
+		// Splice the discriminator in as the first field of the object
+		item := fmt.Appendf(nil, `{"type":%q`, name)
+		if fields = bytes.TrimPrefix(fields, []byte("{")); fields[0] != '}' {
+			item = append(item, ',')
+		}
+		items[i] = append(item, fields...)
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON replaces the contents of the set with the shapes in data.
+// Every decoded shape is validated, and the first invalid one is reported
+// with its index.
+func (s *ShapeSet) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	shapes := make([]Shape, len(items))
+	for i, item := range items {
+		var tag struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(item, &tag); err != nil {
+			return fmt.Errorf("shape %d: %w", i, err)
+		}
+		shape, err := newShape(tag.Type)
+		if err != nil {
+			return fmt.Errorf("shape %d: %w", i, err)
+		}
+		if err := json.Unmarshal(item, shape); err != nil {
+			return fmt.Errorf("shape %d: %w", i, err)
+		}
+		if v, ok := shape.(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return fmt.Errorf("shape %d: %w", i, err)
+			}
+		}
+		shapes[i] = shape
+	}
+	s.shapes = shapes
+	return nil
+}
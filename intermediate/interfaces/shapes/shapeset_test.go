@@ -0,0 +1,52 @@
+package shapes
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestShapeSetJSONRoundTrip(t *testing.T) {
+	c, _ := NewCircle(Point{1, 2}, 3)
+	r, _ := NewRectangle(Point{}, 3, 4)
+	set := NewShapeSet(c, r)
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"type":"circle","center":{"x":1,"y":2},"radius":3},{"type":"rectangle","min":{"x":0,"y":0},"width":3,"height":4}]`
+	if string(data) != want {
+		t.Fatalf("Marshal = %s\nwant %s", data, want)
+	}
+
+	// A ShapeSet value, not just a pointer, must encode the same way
+	if byValue, err := json.Marshal(*set); err != nil || string(byValue) != want {
+		t.Errorf("Marshal of a value = %s, %v; want %s", byValue, err, want)
+	}
+	if inStruct, err := json.Marshal(struct{ S ShapeSet }{*set}); err != nil || string(inStruct) != `{"S":`+want+`}` {
+		t.Errorf("Marshal of a struct field = %s, %v", inStruct, err)
+	}
+
+	var back ShapeSet
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Len() != 2 || *back.At(0).(*Circle) != *c || *back.At(1).(*Rectangle) != *r {
+		t.Errorf("Unmarshal gave %v, %v", back.At(0), back.At(1))
+	}
+}
+
+func TestShapeSetMarshalNil(t *testing.T) {
+	c, _ := NewCircle(Point{}, 1)
+	for _, nilShape := range []Shape{nil, (*Circle)(nil), (*Polygon)(nil)} {
+		_, err := json.Marshal(NewShapeSet(c, nilShape))
+		if !errors.Is(err, ErrNilShape) {
+			t.Errorf("Marshal with %#v: error = %v, want ErrNilShape", nilShape, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "shape 1") {
+			t.Errorf("error %q does not name shape 1", err)
+		}
+	}
+}
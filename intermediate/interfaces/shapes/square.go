@@ -0,0 +1,63 @@
+package shapes
+
+import "fmt"
+
+// Square is an axis-aligned square with its lower-left corner at Min.
+type Square struct {
+	Min  Point   `json:"min"`
+	Side float64 `json:"side"`
+}
+
+// NewSquare returns a validated square.
+func NewSquare(min Point, side float64) (*Square, error) {
+	s := &Square{Min: min, Side: side}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate reports whether s is a real square.
+func (s Square) Validate() error {
+	if err := checkPoints(s.Min); err != nil {
+		return fmt.Errorf("square: %w", err)
+	}
+	if err := checkDimension("side", s.Side); err != nil {
+		return fmt.Errorf("square: %w", err)
+	}
+	return nil
+}
+
+// Area returns side².
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// Perimeter returns 4 × side.
+func (s Square) Perimeter() float64 {
+	return 4 * s.Side
+}
+
+// Bounds returns the square itself.
+func (s Square) Bounds() Box {
+	return Box{Min: s.Min, Max: s.Min.translate(s.Side, s.Side)}
+}
+
+// Contains reports whether p lies inside or on the square.
+func (s Square) Contains(p Point) bool {
+	return s.Bounds().Contains(p)
+}
+
+// Translate moves the square by dx, dy.
+func (s *Square) Translate(dx, dy float64) {
+	s.Min = s.Min.translate(dx, dy)
+}
+
+// Scale multiplies the side by factor, keeping the lower-left corner fixed.
+func (s *Square) Scale(factor float64) error {
+	if err := checkFactor(factor); err != nil {
+		return err
+	}
+	s.Side *= factor
+	return nil
+}
@@ -0,0 +1,193 @@
+package shapes
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// Drawable is implemented by shapes that can render themselves as a single
+// SVG element, in their own coordinates with the y axis pointing up.
+type Drawable interface {
+	SVG() string
+}
+
+// SVG returns a <circle> element.
+func (c Circle) SVG() string {
+	return fmt.Sprintf(`<circle cx="%g" cy="%g" r="%g"/>`, c.Center.X, c.Center.Y, c.Radius)
+}
+
+// SVG returns an <ellipse> element.
+func (e Ellipse) SVG() string {
+	return fmt.Sprintf(`<ellipse cx="%g" cy="%g" rx="%g" ry="%g"/>`, e.Center.X, e.Center.Y, e.RadiusX, e.RadiusY)
+}
+
+// SVG returns a <rect> element.
+func (s Square) SVG() string {
+	return fmt.Sprintf(`<rect x="%g" y="%g" width="%g" height="%g"/>`, s.Min.X, s.Min.Y, s.Side, s.Side)
+}
+
+// SVG returns a <rect> element.
+func (r Rectangle) SVG() string {
+	return fmt.Sprintf(`<rect x="%g" y="%g" width="%g" height="%g"/>`, r.Min.X, r.Min.Y, r.Width, r.Height)
+}
+
+// SVG returns a <polygon> element.
+func (t Triangle) SVG() string {
+	return svgPolygon([]Point{t.A, t.B, t.C})
+}
+
+// SVG returns a <polygon> element.
+func (p Polygon) SVG() string {
+	return svgPolygon(p.Vertices)
+}
+
+// SVG returns a <polygon> element.
+func (r RegularPolygon) SVG() string {
+	return svgPolygon(r.Vertices())
+}
+
+func svgPolygon(vertices []Point) string {
+	points := make([]string, len(vertices))
+	for i, v := range vertices {
+		points[i] = fmt.Sprintf("%g,%g", v.X, v.Y)
+	}
+	return fmt.Sprintf(`<polygon points="%s"/>`, strings.Join(points, " "))
+}
+
+// Canvas lays out shapes in a grid, one per cell, each with a label showing
+// its area and perimeter, and writes them as a standalone SVG document.
+// Every cell uses the same scale, so relative sizes are preserved.
+type Canvas struct {
+	Scale   float64 // pixels per unit of length
+	Padding float64 // pixels around each shape
+	Columns int     // cells per row; 0 puts every shape in one row
+	Fill    string  // SVG fill colour for shapes
+	Stroke  string  // SVG stroke colour for shapes
+
+	items []canvasItem
+}
+
+type canvasItem struct {
+	shape Shape
+	label string
+}
+
+// labelHeight is the space reserved under each cell for its label.
+const labelHeight = 36
+
+// NewCanvas returns a canvas with sensible defaults.
+func NewCanvas() *Canvas {
+	return &Canvas{Scale: 40, Padding: 20, Fill: "#8ecae6", Stroke: "#023047"}
+}
+
+// Add places s in the next free cell. An empty name is replaced with the
+// shape's type; the area and perimeter are always appended. A nil shape
+// has nothing to draw and is rejected with ErrNilShape.
+func (c *Canvas) Add(s Shape, name string) error {
+	if s == nil {
+		return ErrNilShape
+	}
+	if name == "" {
+		name, _ = typeName(s)
+		if name == "" {
+			name = fmt.Sprintf("%T", s)
+		}
+	}
+	c.items = append(c.items, canvasItem{s, name})
+	return nil
+}
+
+// WriteTo writes the SVG document to w.
+func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
+	cols := c.Columns
+	if cols <= 0 || cols > len(c.items) {
+		cols = max(len(c.items), 1)
+	}
+	rows := (len(c.items) + cols - 1) / cols
+
+	// Every cell is big enough for the widest and the tallest shape
+	var maxW, maxH float64
+	for _, it := range c.items {
+		b := it.shape.Bounds()
+		maxW, maxH = math.Max(maxW, b.Width()), math.Max(maxH, b.Height())
+	}
+	cellW := maxW*c.Scale + 2*c.Padding
+	cellH := maxH*c.Scale + 2*c.Padding + labelHeight
+	width, height := float64(cols)*cellW, float64(rows)*cellH
+
+	// The count is taken below the buffer, so it only includes bytes that
+	// reached w
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	fmt.Fprintf(bw, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\">\n",
+		width, height, width, height)
+	fmt.Fprintf(bw, "  <rect width=\"100%%\" height=\"100%%\" fill=\"white\"/>\n")
+	for i, it := range c.items {
+		x0, y0 := float64(i%cols)*cellW, float64(i/cols)*cellH
+		c.writeItem(bw, it, x0, y0, cellW, cellH)
+	}
+	fmt.Fprintf(bw, "</svg>\n")
+	// bufio.Writer keeps the first error from w and returns it here
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// writeItem draws one shape centred in the cell with top-left corner x0, y0.
+func (c *Canvas) writeItem(w io.Writer, it canvasItem, x0, y0, cellW, cellH float64) {
+	b := it.shape.Bounds()
+	drawH := cellH - labelHeight
+
+	// Map shape coordinates to pixels: centre the bounding box in the
+	// drawing area and flip y so that up is up
+	dx := x0 + (cellW-b.Width()*c.Scale)/2 - b.Min.X*c.Scale
+	dy := y0 + (drawH-b.Height()*c.Scale)/2 + b.Max.Y*c.Scale
+	fmt.Fprintf(w, "  <g transform=\"translate(%g %g) scale(%g %g)\" fill=\"%s\" fill-opacity=\"0.7\" stroke=\"%s\" stroke-width=\"2\">\n",
+		dx, dy, c.Scale, -c.Scale, html.EscapeString(c.Fill), html.EscapeString(c.Stroke))
+	if d, ok := it.shape.(Drawable); ok {
+		// vector-effect is not inherited, so it goes on the element itself
+		// to keep the stroke width in pixels rather than shape units
+		fmt.Fprintf(w, "    %s\n", strings.Replace(d.SVG(), "/>", ` vector-effect="non-scaling-stroke"/>`, 1))
+	} else {
+		// Unknown shapes still get their bounding box, dashed
+		fmt.Fprintf(w, "    <rect x=\"%g\" y=\"%g\" width=\"%g\" height=\"%g\" fill=\"none\" stroke-dasharray=\"4 4\" vector-effect=\"non-scaling-stroke\"/>\n",
+			b.Min.X, b.Min.Y, b.Width(), b.Height())
+	}
+	fmt.Fprintf(w, "  </g>\n")
+
+	cx := x0 + cellW/2
+	fmt.Fprintf(w, "  <text x=\"%g\" y=\"%g\" text-anchor=\"middle\" font-family=\"sans-serif\" font-size=\"13\">%s</text>\n",
+		cx, y0+drawH+12, html.EscapeString(it.label))
+	fmt.Fprintf(w, "  <text x=\"%g\" y=\"%g\" text-anchor=\"middle\" font-family=\"sans-serif\" font-size=\"11\" fill=\"#555\">area %.2f, perimeter %.2f</text>\n",
+		cx, y0+drawH+28, it.shape.Area(), it.shape.Perimeter())
+}
+
+// WriteFile writes the SVG document to the named file, replacing it if it exists.
+func (c *Canvas) WriteFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := c.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// countingWriter counts the bytes that w accepts, for WriteTo's result.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
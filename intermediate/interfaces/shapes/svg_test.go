@@ -0,0 +1,150 @@
+package shapes
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShapeSVG(t *testing.T) {
+	tests := []struct {
+		shape Drawable
+		want  string
+	}{
+		{Circle{Point{1, 2}, 3}, `<circle cx="1" cy="2" r="3"/>`},
+		{Ellipse{Point{1, 2}, 3, 4}, `<ellipse cx="1" cy="2" rx="3" ry="4"/>`},
+		{Square{Point{1, 2}, 3}, `<rect x="1" y="2" width="3" height="3"/>`},
+		{Rectangle{Point{1, 2}, 3, 4}, `<rect x="1" y="2" width="3" height="4"/>`},
+		{Triangle{Point{0, 0}, Point{4, 0}, Point{0, 3}}, `<polygon points="0,0 4,0 0,3"/>`},
+		{Polygon{[]Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}, `<polygon points="0,0 1,0 1,1 0,1"/>`},
+		{RegularPolygon{}, `<polygon points=""/>`},
+	}
+	for _, tt := range tests {
+		if got := tt.shape.SVG(); got != tt.want {
+			t.Errorf("%T.SVG() = %s, want %s", tt.shape, got, tt.want)
+		}
+	}
+	// The vertices of a regular polygon are not exact, so only count them
+	got := RegularPolygon{Sides: 6, Radius: 2}.SVG()
+	if !strings.HasPrefix(got, `<polygon points="2,0 `) || strings.Count(got, ",") != 6 {
+		t.Errorf("hexagon SVG() = %s", got)
+	}
+}
+
+// blob is a Shape that cannot draw itself.
+type blob struct{}
+
+func (blob) Area() float64         { return 1 }
+func (blob) Perimeter() float64    { return 4 }
+func (blob) Bounds() Box           { return Box{Point{0, 0}, Point{1, 1}} }
+func (blob) Contains(p Point) bool { return Box{Point{0, 0}, Point{1, 1}}.Contains(p) }
+
+func TestCanvasAdd(t *testing.T) {
+	c := NewCanvas()
+	if err := c.Add(nil, "nothing"); !errors.Is(err, ErrNilShape) {
+		t.Errorf("Add(nil) = %v, want ErrNilShape", err)
+	}
+	if len(c.items) != 0 {
+		t.Errorf("Add(nil) kept %d items", len(c.items))
+	}
+	for _, s := range []Shape{&Circle{Radius: 1}, Square{Side: 1}, blob{}} {
+		if err := c.Add(s, ""); err != nil {
+			t.Fatalf("Add(%T) = %v", s, err)
+		}
+	}
+	c.Add(blob{}, "named")
+	want := []string{"circle", "square", "shapes.blob", "named"}
+	for i, it := range c.items {
+		if it.label != want[i] {
+			t.Errorf("label %d = %q, want %q", i, it.label, want[i])
+		}
+	}
+}
+
+func TestCanvasWriteTo(t *testing.T) {
+	c := NewCanvas()
+	c.Columns = 2
+	c.Add(&Rectangle{Width: 2, Height: 1}, "a <rect>")
+	c.Add(&Circle{Radius: 1}, "")
+	c.Add(blob{}, "")
+
+	var buf bytes.Buffer
+	n, err := c.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, but wrote %d bytes", n, buf.Len())
+	}
+	doc := buf.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		// Two columns and two rows of cells fitting 2x2 units at 40 px,
+		// with 20 px of padding and the label under each
+		`width="240" height="312"`,
+		`<rect x="0" y="0" width="2" height="1" vector-effect="non-scaling-stroke"/>`,
+		`<circle cx="0" cy="0" r="1" vector-effect="non-scaling-stroke"/>`,
+		`stroke-dasharray="4 4"`, // blob has no SVG of its own
+		`>a &lt;rect&gt;</text>`,
+		`>area 2.00, perimeter 6.00</text>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document is missing %s:\n%s", want, doc)
+		}
+	}
+	if !strings.HasSuffix(doc, "</svg>\n") {
+		t.Errorf("document does not end with </svg>")
+	}
+}
+
+// limitWriter accepts n bytes and then fails.
+type limitWriter struct{ n int }
+
+var errFull = errors.New("full")
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errFull
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestCanvasWriteToError(t *testing.T) {
+	c := NewCanvas()
+	for range 20 {
+		c.Add(&Circle{Radius: 1}, "")
+	}
+	// Fail partway through the first buffer flush and after it
+	for _, limit := range []int{0, 100, 5000} {
+		n, err := c.WriteTo(&limitWriter{limit})
+		if !errors.Is(err, errFull) {
+			t.Errorf("limit %d: error = %v, want errFull", limit, err)
+		}
+		if n != int64(limit) {
+			t.Errorf("limit %d: WriteTo returned %d, want the %d bytes accepted", limit, n, limit)
+		}
+	}
+}
+
+func TestCanvasWriteFile(t *testing.T) {
+	c := NewCanvas()
+	c.Add(&Square{Side: 1}, "")
+	name := filepath.Join(t.TempDir(), "shapes.svg")
+	if err := c.WriteFile(name); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	c.WriteTo(&buf)
+	if got, err := os.ReadFile(name); err != nil || !bytes.Equal(got, buf.Bytes()) {
+		t.Errorf("WriteFile wrote %d bytes (%v), want the %d from WriteTo", len(got), err, buf.Len())
+	}
+	if err := c.WriteFile(filepath.Join(t.TempDir(), "missing", "shapes.svg")); err == nil {
+		t.Error("WriteFile into a missing directory succeeded")
+	}
+}
@@ -0,0 +1,81 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+)
+
+// Triangle is given by its three vertices, in either winding order.
+type Triangle struct {
+	A Point `json:"a"`
+	B Point `json:"b"`
+	C Point `json:"c"`
+}
+
+// NewTriangle returns a validated triangle.
+func NewTriangle(a, b, c Point) (*Triangle, error) {
+	t := &Triangle{A: a, B: b, C: c}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Validate reports whether t is a real triangle, with vertices that are not collinear.
+func (t Triangle) Validate() error {
+	if err := checkPoints(t.A, t.B, t.C); err != nil {
+		return fmt.Errorf("triangle: %w", err)
+	}
+	if orientation(t.A, t.B, t.C) == 0 {
+		return fmt.Errorf("triangle: vertices are collinear: %w", ErrDegenerate)
+	}
+	return nil
+}
+
+// Area returns half the absolute cross product of two edges.
+func (t Triangle) Area() float64 {
+	return math.Abs(cross(t.A, t.B, t.C)) / 2
+}
+
+// Perimeter returns the sum of the three side lengths.
+func (t Triangle) Perimeter() float64 {
+	return t.A.Distance(t.B) + t.B.Distance(t.C) + t.C.Distance(t.A)
+}
+
+// Bounds returns the box that just encloses the triangle.
+func (t Triangle) Bounds() Box {
+	return boundsOf([]Point{t.A, t.B, t.C})
+}
+
+// Contains reports whether p lies inside or on the triangle: p must be on
+// the same side of all three edges.
+func (t Triangle) Contains(p Point) bool {
+	d1, d2, d3 := orientation(t.A, t.B, p), orientation(t.B, t.C, p), orientation(t.C, t.A, p)
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(hasNeg && hasPos)
+}
+
+// Centroid returns the average of the three vertices.
+func (t Triangle) Centroid() Point {
+	return Point{(t.A.X + t.B.X + t.C.X) / 3, (t.A.Y + t.B.Y + t.C.Y) / 3}
+}
+
+// Translate moves the triangle by dx, dy.
+func (t *Triangle) Translate(dx, dy float64) {
+	t.A = t.A.translate(dx, dy)
+	t.B = t.B.translate(dx, dy)
+	t.C = t.C.translate(dx, dy)
+}
+
+// Scale resizes the triangle by factor about its centroid.
+func (t *Triangle) Scale(factor float64) error {
+	if err := checkFactor(factor); err != nil {
+		return err
+	}
+	c := t.Centroid()
+	t.A = t.A.scaleAbout(c, factor)
+	t.B = t.B.scaleAbout(c, factor)
+	t.C = t.C.scaleAbout(c, factor)
+	return nil
+}
@@ -0,0 +1,108 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+)
+
+// Epsilon is the relative tolerance used when deciding whether three points
+// are collinear or two values are equal. It is a package variable so that
+// callers working at unusual scales can tune it before doing any work.
+var Epsilon = 1e-9
+
+// Vector is a displacement in the plane, such as the difference of two points.
+type Vector struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func (v Vector) String() string {
+	return fmt.Sprintf("<%g, %g>", v.X, v.Y)
+}
+
+// Add returns v + w.
+func (v Vector) Add(w Vector) Vector {
+	return Vector{v.X + w.X, v.Y + w.Y}
+}
+
+// Sub returns v - w.
+func (v Vector) Sub(w Vector) Vector {
+	return Vector{v.X - w.X, v.Y - w.Y}
+}
+
+// Scale returns v multiplied by k.
+func (v Vector) Scale(k float64) Vector {
+	return Vector{v.X * k, v.Y * k}
+}
+
+// Dot returns the dot product v · w.
+func (v Vector) Dot(w Vector) float64 {
+	return v.X*w.X + v.Y*w.Y
+}
+
+// Cross returns the z component of the cross product v × w. It is positive
+// when w points counter-clockwise of v.
+func (v Vector) Cross(w Vector) float64 {
+	return v.X*w.Y - v.Y*w.X
+}
+
+// Length returns the Euclidean length of v.
+func (v Vector) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Add returns p moved by v.
+func (p Point) Add(v Vector) Point {
+	return Point{p.X + v.X, p.Y + v.Y}
+}
+
+// Sub returns the vector from q to p.
+func (p Point) Sub(q Point) Vector {
+	return Vector{p.X - q.X, p.Y - q.Y}
+}
+
+// Distance returns the Euclidean distance between p and q.
+func (p Point) Distance(q Point) float64 {
+	return p.Sub(q).Length()
+}
+
+// Equal reports whether p and q are the same point within Epsilon.
+func (p Point) Equal(q Point) bool {
+	return nearlyEqual(p.X, q.X) && nearlyEqual(p.Y, q.Y)
+}
+
+// translate returns p moved by dx, dy.
+func (p Point) translate(dx, dy float64) Point {
+	return p.Add(Vector{dx, dy})
+}
+
+// scaleAbout returns p moved so its distance from origin is multiplied by factor.
+func (p Point) scaleAbout(origin Point, factor float64) Point {
+	return origin.Add(p.Sub(origin).Scale(factor))
+}
+
+// nearlyEqual compares a and b with a tolerance of Epsilon, relative to
+// their magnitude once that exceeds 1.
+func nearlyEqual(a, b float64) bool {
+	return math.Abs(a-b) <= Epsilon*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// cross returns (b-a) × (c-a).
+func cross(a, b, c Point) float64 {
+	return b.Sub(a).Cross(c.Sub(a))
+}
+
+// orientation returns 1 if a, b, c turn counter-clockwise, -1 if they turn
+// clockwise and 0 if they are collinear within Epsilon. The tolerance is
+// applied to the sine of the angle at a, so it does not depend on scale.
+func orientation(a, b, c Point) int {
+	ab, ac := b.Sub(a), c.Sub(a)
+	z := ab.Cross(ac)
+	if math.Abs(z) <= Epsilon*ab.Length()*ac.Length() {
+		return 0
+	}
+	if z > 0 {
+		return 1
+	}
+	return -1
+}
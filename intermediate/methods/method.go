@@ -1,8 +1,15 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"intermediate/interfaces/shapes"
+)
 
 // ✅ Struct Definition
+// (The shapes package in intermediate/interfaces has an exported Rectangle
+// with the same methods plus the rest of the Shape interface. This one
+// keeps only what the lesson needs.)
 type Rectangle struct {
 	Length  float64
 	Breadth float64
@@ -15,18 +22,24 @@ func (r Rectangle) Area() float64 {
 	return r.Length * r.Breadth
 }
 
-// ✅ Method with Pointer Receiver
-// This method modifies the original struct values
-func (r *Rectangle) DoubleDimensions() {
-	r.Length *= 2
-	r.Breadth *= 2
-}
-
 // ✅ Method with Value Receiver: Perimeter Calculation
 func (r Rectangle) Perimeter() float64 {
 	return 2 * (r.Length + r.Breadth)
 }
 
+// ✅ Method with Pointer Receiver
+// This method modifies the original struct values. Scale(2) doubles the
+// dimensions; a factor that is not positive is rejected with an error
+// instead of producing a negative rectangle.
+func (r *Rectangle) Scale(factor float64) error {
+	if !(factor > 0) {
+		return fmt.Errorf("scale: factor %g is not positive", factor)
+	}
+	r.Length *= factor
+	r.Breadth *= factor
+	return nil
+}
+
 // ✅ Method with Pointer Receiver: Reset dimensions to zero
 func (r *Rectangle) Reset() {
 	r.Length = 0
@@ -46,17 +59,41 @@ func main() {
 	fmt.Println("Perimeter:", perimeter) // Output: Perimeter: 16
 
 	// 🔹 Call pointer receiver method - modifies struct
-	rect.DoubleDimensions()
-	fmt.Println("After Doubling - Length:", rect.Length, "Breadth:", rect.Breadth)
-	// Output: After Doubling - Length: 10 Breadth: 6
+	// Go takes &rect automatically because rect is addressable
+	if err := rect.Scale(2); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("After Scale(2) - Length:", rect.Length, "Breadth:", rect.Breadth)
+	// Output: After Scale(2) - Length: 10 Breadth: 6
+
+	// 🔹 A pointer method can refuse to change the struct
+	if err := rect.Scale(-1); err != nil {
+		fmt.Println("Scale(-1) error:", err)
+	}
 
 	// 🔹 Call Reset method to zero the fields
 	rect.Reset()
 	fmt.Println("After Reset - Length:", rect.Length, "Breadth:", rect.Breadth)
 	// Output: After Reset - Length: 0 Breadth: 0
 
-	// ✅ Extra: You can also call pointer methods using value type, Go auto-converts!
-	anotherRect := Rectangle{Length: 2, Breadth: 2}
-	anotherRect.DoubleDimensions() // even though it's not a pointer
-	fmt.Println("Another Rect Doubled - Length:", anotherRect.Length, "Breadth:", anotherRect.Breadth)
+	// ✅ Method sets: only *Rectangle has Scale and Reset, so only a pointer
+	// satisfies the shapes package's Scalable and Resettable interfaces.
+	// Rectangle never mentions them; having the methods is enough.
+	var sc shapes.Scalable = &Rectangle{Length: 2, Breadth: 2}
+	if err := sc.Scale(2); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("Pointer is Scalable, area now:", sc.(*Rectangle).Area()) // Output: 16
+
+	var v any = Rectangle{Length: 2, Breadth: 2}
+	if _, ok := v.(shapes.Resettable); !ok {
+		fmt.Println("Value is not Resettable: Reset needs a pointer receiver")
+	}
+
+	// The exported shapes.Rectangle behaves the same way
+	var s shapes.Shape = &shapes.Rectangle{Width: 2, Height: 2}
+	if r, ok := s.(shapes.Resettable); ok {
+		r.Reset()
+		fmt.Println("shapes.Rectangle pointer is Resettable, area now:", s.Area()) // Output: 0
+	}
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+)
+
+var errNegative = errors.New("recursion: negative input")
+
+// bigFactorial returns n! with arbitrary precision, so it never overflows
+func bigFactorial(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errNegative
+	}
+	return new(big.Int).MulRange(1, int64(n)), nil
+}
+
+// bigBinomial returns n choose k with arbitrary precision.
+// It is 0 when k is negative or larger than n.
+func bigBinomial(n, k int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errNegative
+	}
+	if k < 0 || k > n {
+		return new(big.Int), nil
+	}
+	return new(big.Int).Binomial(int64(n), int64(k)), nil
+}
+
+// bigFibonacci returns the nth Fibonacci number with arbitrary precision
+func bigFibonacci(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errNegative
+	}
+	f, _ := fibonacciPair(n)
+	return f, nil
+}
+
+// fibonacciPair returns F(n) and F(n+1) using the "fast doubling" identities
+//
+//	F(2k)   = F(k) * (2*F(k+1) - F(k))
+//	F(2k+1) = F(k)^2 + F(k+1)^2
+//
+// so the recursion is only log2(n) calls deep instead of n.
+func fibonacciPair(n int) (*big.Int, *big.Int) {
+	if n == 0 {
+		return big.NewInt(0), big.NewInt(1)
+	}
+	a, b := fibonacciPair(n / 2)
+
+	// c = F(2k), d = F(2k+1)
+	c := new(big.Int).Lsh(b, 1)
+	c.Sub(c, a).Mul(c, a)
+	d := new(big.Int).Mul(a, a)
+	d.Add(d, new(big.Int).Mul(b, b))
+
+	if n%2 == 0 {
+		return c, d
+	}
+	return d, c.Add(c, d)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"math"
+
+	"intermediate/generics/numeric"
+)
+
+// errOverflow is the checked arithmetic's own error, so callers can test for
+// either name with errors.Is.
+var errOverflow = numeric.ErrOverflow
+
+// maxFactorial is the largest n whose factorial fits in a 64-bit int.
+const maxFactorial = 20
+
+// factorialChecked is factorial with errors instead of silent wrap-around.
+// Anything above maxFactorial is rejected before recursing, so a huge n
+// fails at once instead of exhausting the stack.
+func factorialChecked(n int) (int, error) {
+	if n < 0 {
+		return 0, errNegative
+	}
+	if n > maxFactorial {
+		return 0, errOverflow
+	}
+	if n == 0 || n == 1 {
+		return 1, nil
+	}
+	smallOutput, err := factorialChecked(n - 1)
+	if err != nil {
+		return 0, err
+	}
+	// Still checked, since int may be only 32 bits wide
+	return numeric.MulChecked(smallOutput, n)
+}
+
+// binomialChecked returns n choose k, or errOverflow if it does not fit in an int.
+// The intermediate products can overflow even when the answer fits, so the
+// exact value is computed first and then range checked.
+func binomialChecked(n, k int) (int, error) {
+	b, err := bigBinomial(n, k)
+	if err != nil {
+		return 0, err
+	}
+	if !b.IsInt64() || b.Int64() > math.MaxInt {
+		return 0, errOverflow
+	}
+	return int(b.Int64()), nil
+}
+
+// fibonacciChecked returns the nth Fibonacci number, or errOverflow if it
+// does not fit in an int. F(92) is the largest that fits in 64 bits.
+func fibonacciChecked(n int) (int, error) {
+	if n < 0 {
+		return 0, errNegative
+	}
+	a, b := 0, 1
+	for i := 0; i < n; i++ {
+		next, err := numeric.AddChecked(a, b)
+		if err != nil {
+			// next would be F(i+2); it only matters if we still need it
+			if i+1 < n {
+				return 0, err
+			}
+			return b, nil
+		}
+		a, b = b, next
+	}
+	return a, nil
+}
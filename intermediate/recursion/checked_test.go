@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFactorialChecked(t *testing.T) {
+	got, err := factorialChecked(20)
+	if err != nil || got != 2432902008176640000 {
+		t.Errorf("factorialChecked(20) = %d, %v; want 2432902008176640000, nil", got, err)
+	}
+	if _, err := factorialChecked(21); !errors.Is(err, errOverflow) {
+		t.Errorf("factorialChecked(21) error = %v, want errOverflow", err)
+	}
+	// Rejected up front: recursing this deep would overflow the stack
+	if _, err := factorialChecked(200_000_000); !errors.Is(err, errOverflow) {
+		t.Errorf("factorialChecked(200_000_000) error = %v, want errOverflow", err)
+	}
+	if _, err := factorialChecked(math.MaxInt); !errors.Is(err, errOverflow) {
+		t.Errorf("factorialChecked(MaxInt) error = %v, want errOverflow", err)
+	}
+	if _, err := factorialChecked(-1); !errors.Is(err, errNegative) {
+		t.Errorf("factorialChecked(-1) error = %v, want errNegative", err)
+	}
+}
+
+func TestFibonacciChecked(t *testing.T) {
+	got, err := fibonacciChecked(92)
+	if err != nil || got != 7540113804746346429 {
+		t.Errorf("fibonacciChecked(92) = %d, %v; want 7540113804746346429, nil", got, err)
+	}
+	if _, err := fibonacciChecked(93); !errors.Is(err, errOverflow) {
+		t.Errorf("fibonacciChecked(93) error = %v, want errOverflow", err)
+	}
+	for n, want := range []int{0, 1, 1, 2, 3, 5, 8} {
+		if got, err := fibonacciChecked(n); err != nil || got != want {
+			t.Errorf("fibonacciChecked(%d) = %d, %v; want %d", n, got, err, want)
+		}
+	}
+}
+
+func TestBinomialChecked(t *testing.T) {
+	// C(66, 33) fits in an int even though 66! does not
+	got, err := binomialChecked(66, 33)
+	if err != nil || got != 7219428434016265740 {
+		t.Errorf("binomialChecked(66, 33) = %d, %v", got, err)
+	}
+	if _, err := binomialChecked(68, 34); !errors.Is(err, errOverflow) {
+		t.Errorf("binomialChecked(68, 34) error = %v, want errOverflow", err)
+	}
+}
+
+func TestSumOfNumbersFormula(t *testing.T) {
+	tests := []struct {
+		n    int
+		want int
+		err  error
+	}{
+		{0, 0, nil},
+		{1, 1, nil},
+		{100, 5050, nil},
+		{4294967295, 9223372034707292160, nil}, // the largest n whose sum fits
+		{4294967296, 0, errOverflow},
+		{math.MaxInt, 0, errOverflow},
+		{-1, 0, errNegative},
+		{math.MinInt, 0, errNegative},
+	}
+	for _, tt := range tests {
+		got, err := sumOfNumbersFormula(tt.n)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("sumOfNumbersFormula(%d) = %d, %v; want %d, %v", tt.n, got, err, tt.want, tt.err)
+		}
+	}
+	for n := range 50 {
+		if got, _ := sumOfNumbersFormula(n); got != sumOfNumbersIterative(n) {
+			t.Errorf("sumOfNumbersFormula(%d) = %d, want %d", n, got, sumOfNumbersIterative(n))
+		}
+	}
+}
+
+func TestBigFactorial(t *testing.T) {
+	if got, err := bigFactorial(0); err != nil || got.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("bigFactorial(0) = %v, %v; want 1", got, err)
+	}
+	got, err := bigFactorial(25)
+	if err != nil || got.String() != "15511210043330985984000000" {
+		t.Errorf("bigFactorial(25) = %v, %v", got, err)
+	}
+	if _, err := bigFactorial(-1); !errors.Is(err, errNegative) {
+		t.Errorf("bigFactorial(-1) error = %v, want errNegative", err)
+	}
+}
+
+func TestBigBinomial(t *testing.T) {
+	for _, k := range []int{-1, 11} {
+		if got, err := bigBinomial(10, k); err != nil || got.Sign() != 0 {
+			t.Errorf("bigBinomial(10, %d) = %v, %v; want 0", k, got, err)
+		}
+	}
+	if got, err := bigBinomial(10, 3); err != nil || got.Int64() != 120 {
+		t.Errorf("bigBinomial(10, 3) = %v, %v; want 120", got, err)
+	}
+	if _, err := bigBinomial(-1, 0); !errors.Is(err, errNegative) {
+		t.Errorf("bigBinomial(-1, 0) error = %v, want errNegative", err)
+	}
+}
+
+// TestBigFibonacciLarge checks fast doubling against plain iteration far
+// beyond the range of int.
+func TestBigFibonacciLarge(t *testing.T) {
+	a, b := big.NewInt(0), big.NewInt(1)
+	for n := 0; n <= 5000; n++ {
+		if n%97 == 0 || n == 5000 {
+			got, err := bigFibonacci(n)
+			if err != nil || got.Cmp(a) != 0 {
+				t.Fatalf("bigFibonacci(%d) = %v, %v; want %v", n, got, err, a)
+			}
+		}
+		a, b = b, a.Add(a, b)
+	}
+	if _, err := bigFibonacci(-1); !errors.Is(err, errNegative) {
+		t.Errorf("bigFibonacci(-1) error = %v, want errNegative", err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import "iter"
+
+// permutations yields every ordering of items. Each yielded slice is a fresh
+// copy, and stopping the range loop early stops the recursion too.
+func permutations[T any](items []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		current := append([]T(nil), items...)
+		var permute func(k int) bool
+		// permute fixes positions [0, k) and tries every remaining item at k
+		permute = func(k int) bool {
+			if k == len(current) {
+				return yield(append([]T(nil), current...))
+			}
+			for i := k; i < len(current); i++ {
+				current[k], current[i] = current[i], current[k]
+				ok := permute(k + 1)
+				current[k], current[i] = current[i], current[k]
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		permute(0)
+	}
+}
+
+// combinations yields every way of choosing k of items, keeping their order
+func combinations[T any](items []T, k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if k < 0 || k > len(items) {
+			return
+		}
+		chosen := make([]T, 0, k)
+		var choose func(start int) bool
+		choose = func(start int) bool {
+			if len(chosen) == k {
+				return yield(append([]T(nil), chosen...))
+			}
+			// Stop early when too few items remain to fill the selection
+			for i := start; i <= len(items)-(k-len(chosen)); i++ {
+				chosen = append(chosen, items[i])
+				ok := choose(i + 1)
+				chosen = chosen[:len(chosen)-1]
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		choose(0)
+	}
+}
+
+// powerSet yields every subset of items, starting with the empty set
+func powerSet[T any](items []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		subset := make([]T, 0, len(items))
+		var build func(i int) bool
+		// build decides, for each item in turn, whether to leave it out or take it
+		build = func(i int) bool {
+			if i == len(items) {
+				return yield(append([]T(nil), subset...))
+			}
+			if !build(i + 1) {
+				return false
+			}
+			subset = append(subset, items[i])
+			ok := build(i + 1)
+			subset = subset[:len(subset)-1]
+			return ok
+		}
+		build(0)
+	}
+}
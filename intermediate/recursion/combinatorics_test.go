@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestCombinationsPascal(t *testing.T) {
+	// Count C(n, k) by enumeration and check each row of Pascal's triangle
+	row := []int{1}
+	for n := 0; n <= 10; n++ {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = i
+		}
+		for k := -1; k <= n+1; k++ {
+			want := 0
+			if k >= 0 && k <= n {
+				want = row[k]
+			}
+			got := 0
+			for c := range combinations(items, k) {
+				got++
+				if len(c) != k || !slices.IsSorted(c) {
+					t.Fatalf("combinations(%d, %d) yielded %v", n, k, c)
+				}
+			}
+			if got != want {
+				t.Errorf("C(%d, %d) = %d, want %d", n, k, got, want)
+			}
+		}
+		next := make([]int, n+2)
+		next[0], next[n+1] = 1, 1
+		for k := 1; k <= n; k++ {
+			next[k] = row[k-1] + row[k]
+		}
+		row = next
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	got := slices.Collect(combinations([]string{"a", "b", "c", "d"}, 2))
+	want := [][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("combinations = %v, want %v", got, want)
+	}
+	if got := slices.Collect(combinations([]int{1, 2}, 0)); len(got) != 1 || len(got[0]) != 0 {
+		t.Errorf("combinations(k=0) = %v, want one empty selection", got)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	factorial := 1
+	for n := 0; n <= 7; n++ {
+		if n > 0 {
+			factorial *= n
+		}
+		items := make([]int, n)
+		for i := range items {
+			items[i] = i
+		}
+		seen := make(map[string]bool)
+		for p := range permutations(items) {
+			seen[fmt.Sprint(p)] = true
+		}
+		if len(seen) != factorial {
+			t.Errorf("permutations of %d items: %d distinct, want %d", n, len(seen), factorial)
+		}
+	}
+
+	items := []int{1, 2, 3}
+	var first []int
+	for p := range permutations(items) {
+		first = p
+		break
+	}
+	if !slices.Equal(first, []int{1, 2, 3}) || !slices.Equal(items, []int{1, 2, 3}) {
+		t.Errorf("first permutation %v, input now %v", first, items)
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	got := slices.Collect(powerSet([]int{1, 2, 3}))
+	want := [][]int{{}, {3}, {2}, {2, 3}, {1}, {1, 3}, {1, 2}, {1, 2, 3}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("powerSet = %v, want %v", got, want)
+	}
+	for n := 0; n <= 12; n++ {
+		count := 0
+		for range powerSet(make([]int, n)) {
+			count++
+		}
+		if count != 1<<n {
+			t.Errorf("powerSet of %d items has %d subsets, want %d", n, count, 1<<n)
+		}
+	}
+	count := 0
+	for range powerSet([]int{1, 2, 3, 4}) {
+		if count++; count == 5 {
+			break
+		}
+	}
+	if count != 5 {
+		t.Errorf("stopping early yielded %d subsets", count)
+	}
+}
@@ -1,10 +1,15 @@
 package main
 
 func factorial(n int) int {
+	return factorialStep(factorial, n)
+}
+
+// factorialStep is the body of factorial, with the recursive call made
+// through self so that a tracer can stand in for it and see every level
+func factorialStep(self func(int) int, n int) int {
 	if n == 0 || n == 1 {
 		return 1
 	}
-	smallOutput := factorial(n-1)
+	smallOutput := self(n - 1)
 	return smallOutput * n
-	
-}
\ No newline at end of file
+}
@@ -0,0 +1,40 @@
+package main
+
+import "math/big"
+
+// fibonacci is the textbook definition. It makes an exponential number of
+// calls because fibonacci(n-2) is recomputed inside fibonacci(n-1).
+func fibonacci(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fibonacci(n-1) + fibonacci(n-2)
+}
+
+// newMemoFibonacci returns the textbook definition with each result cached,
+// so every n is computed once and the call count becomes linear
+func newMemoFibonacci() *memo[int, *big.Int] {
+	var fib *memo[int, *big.Int]
+	fib = memoize(func(n int) *big.Int {
+		if n < 2 {
+			return big.NewInt(int64(n))
+		}
+		return new(big.Int).Add(fib.call(n-1), fib.call(n-2))
+	}, 0)
+	return fib
+}
+
+// fibonacciTrampolined carries the last two values down as accumulators, so
+// it is tail recursive and can run on the trampoline without growing the stack
+func fibonacciTrampolined(n int) *big.Int {
+	var step func(n int, a, b *big.Int) thunk[*big.Int]
+	step = func(n int, a, b *big.Int) thunk[*big.Int] {
+		if n <= 0 {
+			return done(a)
+		}
+		return more(func() thunk[*big.Int] {
+			return step(n-1, b, new(big.Int).Add(a, b))
+		})
+	}
+	return trampoline(step(n, big.NewInt(0), big.NewInt(1)))
+}
@@ -0,0 +1,26 @@
+package main
+
+import "fmt"
+
+// hanoiMove moves the top disk from one peg to another
+type hanoiMove struct {
+	disk     int
+	from, to string
+}
+
+func (m hanoiMove) String() string {
+	return fmt.Sprintf("move disk %d from %s to %s", m.disk, m.from, m.to)
+}
+
+// hanoi returns the 2^n - 1 moves that transfer n disks from peg from to peg
+// to, using via as the spare peg
+func hanoi(n int, from, to, via string) []hanoiMove {
+	if n <= 0 {
+		return nil
+	}
+	// Park the n-1 smaller disks on the spare peg, move the largest disk,
+	// then bring the smaller disks back on top of it
+	moves := hanoi(n-1, from, via, to)
+	moves = append(moves, hanoiMove{n, from, to})
+	return append(moves, hanoi(n-1, via, to, from)...)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHanoi(t *testing.T) {
+	for n := 0; n <= 12; n++ {
+		moves := hanoi(n, "A", "C", "B")
+		if want := 1<<n - 1; len(moves) != want {
+			t.Errorf("hanoi(%d) made %d moves, want %d", n, len(moves), want)
+		}
+
+		// Replay the moves, checking that no disk lands on a smaller one
+		pegs := map[string][]int{"A": nil, "B": nil, "C": nil}
+		for d := n; d >= 1; d-- {
+			pegs["A"] = append(pegs["A"], d)
+		}
+		for i, m := range moves {
+			from := pegs[m.from]
+			if len(from) == 0 || from[len(from)-1] != m.disk {
+				t.Fatalf("hanoi(%d) move %d: disk %d is not on top of %s", n, i, m.disk, m.from)
+			}
+			if to := pegs[m.to]; len(to) > 0 && to[len(to)-1] < m.disk {
+				t.Fatalf("hanoi(%d) move %d: disk %d put on disk %d", n, i, m.disk, to[len(to)-1])
+			}
+			pegs[m.from] = from[:len(from)-1]
+			pegs[m.to] = append(pegs[m.to], m.disk)
+		}
+		if len(pegs["A"]) != 0 || len(pegs["B"]) != 0 || len(pegs["C"]) != n {
+			t.Errorf("hanoi(%d) ended with %v", n, pegs)
+		}
+	}
+	if got := hanoi(-1, "A", "C", "B"); got != nil {
+		t.Errorf("hanoi(-1) = %v, want no moves", got)
+	}
+}
+
+func TestHanoiMoves(t *testing.T) {
+	want := []hanoiMove{{1, "A", "C"}, {2, "A", "B"}, {1, "C", "B"}, {3, "A", "C"}, {1, "B", "A"}, {2, "B", "C"}, {1, "A", "C"}}
+	if got := hanoi(3, "A", "C", "B"); !slices.Equal(got, want) {
+		t.Errorf("hanoi(3) = %v, want %v", got, want)
+	}
+	if got := want[0].String(); got != "move disk 1 from A to C" {
+		t.Errorf("String = %q", got)
+	}
+}
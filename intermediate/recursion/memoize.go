@@ -0,0 +1,97 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+)
+
+// memoStats counts how a memo cache has been used
+type memoStats struct {
+	hits      int
+	misses    int
+	evictions int
+}
+
+// memo caches the results of a pure function. When the cache is bounded the
+// least recently used entry is evicted to make room for a new one.
+type memo[K comparable, V any] struct {
+	mu       sync.Mutex
+	f        func(K) V
+	capacity int
+	entries  map[K]*list.Element
+	order    *list.List // front is most recently used; values are memoEntry
+	stats    memoStats
+}
+
+type memoEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// memoize wraps f so each argument is computed at most once while it stays in
+// the cache. capacity limits the number of cached results; 0 means unbounded.
+//
+// A recursive function memoizes itself by calling the wrapper, not f:
+//
+//	var fib *memo[int, int]
+//	fib = memoize(func(n int) int {
+//		if n < 2 {
+//			return n
+//		}
+//		return fib.call(n-1) + fib.call(n-2)
+//	}, 0)
+func memoize[K comparable, V any](f func(K) V, capacity int) *memo[K, V] {
+	return &memo[K, V]{
+		f:        f,
+		capacity: capacity,
+		entries:  make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// call returns f(key), computing it only on a cache miss
+func (m *memo[K, V]) call(key K) V {
+	m.mu.Lock()
+	if e, ok := m.entries[key]; ok {
+		m.stats.hits++
+		m.order.MoveToFront(e)
+		value := e.Value.(memoEntry[K, V]).value
+		m.mu.Unlock()
+		return value
+	}
+	m.stats.misses++
+	m.mu.Unlock()
+
+	// f is called without holding the lock because it may recurse into call
+	value := m.f(key)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		// A recursive call already stored this key
+		m.order.MoveToFront(e)
+		return value
+	}
+	m.entries[key] = m.order.PushFront(memoEntry[K, V]{key, value})
+	if m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(memoEntry[K, V]).key)
+		m.stats.evictions++
+	}
+	return value
+}
+
+// snapshot returns the current hit, miss and eviction counts
+func (m *memo[K, V]) snapshot() memoStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
+
+// size returns the number of cached results
+func (m *memo[K, V]) size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMemoizeFibonacci(t *testing.T) {
+	var fib *memo[int, int]
+	calls := 0
+	fib = memoize(func(n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return fib.call(n-1) + fib.call(n-2)
+	}, 0)
+
+	if got := fib.call(90); got != 2880067194370816120 {
+		t.Errorf("fib(90) = %d", got)
+	}
+	// Each of 0..90 is computed once. The second call in f(n) hits the
+	// cache for every n from 3 up; f(2) is the first to need 0
+	if calls != 91 {
+		t.Errorf("f called %d times, want 91", calls)
+	}
+	if s := fib.snapshot(); s.misses != 91 || s.hits != 88 || s.evictions != 0 {
+		t.Errorf("stats = %+v", s)
+	}
+	fib.call(90)
+	if s := fib.snapshot(); s.hits != 89 || calls != 91 {
+		t.Errorf("after a repeat: stats = %+v, calls %d", s, calls)
+	}
+}
+
+func TestMemoizeLRU(t *testing.T) {
+	calls := map[string]int{}
+	m := memoize(func(s string) int {
+		calls[s]++
+		return len(s)
+	}, 2)
+
+	m.call("a")
+	m.call("bb")
+	m.call("a")   // a is now the most recently used
+	m.call("ccc") // evicts bb
+	m.call("a")
+	m.call("bb") // recomputed, evicts ccc
+
+	if calls["a"] != 1 || calls["bb"] != 2 || calls["ccc"] != 1 {
+		t.Errorf("calls = %v", calls)
+	}
+	if s := m.snapshot(); s.hits != 2 || s.misses != 4 || s.evictions != 2 || m.size() != 2 {
+		t.Errorf("stats = %+v, size %d", s, m.size())
+	}
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	m := memoize(func(n int) int { return n * n }, 16)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				if n := (i + g) % 32; m.call(n) != n*n {
+					t.Errorf("call(%d) is wrong", n)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if m.size() > 16 {
+		t.Errorf("size %d is over the capacity", m.size())
+	}
+}
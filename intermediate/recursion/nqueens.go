@@ -0,0 +1,56 @@
+package main
+
+import (
+	"iter"
+	"strings"
+)
+
+// nQueens yields every placement of n queens on an n×n board so that no two
+// attack each other. A solution lists the column of the queen in each row.
+// There are none unless n is positive.
+func nQueens(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if n <= 0 {
+			return
+		}
+		cols := make([]int, 0, n)
+		usedCol := make([]bool, n)
+		usedDiag := make([]bool, 2*n)     // row + col is constant along a "/" diagonal
+		usedAntiDiag := make([]bool, 2*n) // row - col + n is constant along a "\" diagonal
+
+		var place func(row int) bool
+		place = func(row int) bool {
+			if row == n {
+				return yield(append([]int(nil), cols...))
+			}
+			for col := 0; col < n; col++ {
+				d, a := row+col, row-col+n
+				if usedCol[col] || usedDiag[d] || usedAntiDiag[a] {
+					continue
+				}
+				usedCol[col], usedDiag[d], usedAntiDiag[a] = true, true, true
+				cols = append(cols, col)
+				ok := place(row + 1)
+				cols = cols[:row]
+				usedCol[col], usedDiag[d], usedAntiDiag[a] = false, false, false
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		place(0)
+	}
+}
+
+// boardString draws an n-queens solution with Q for queens and . for empty squares
+func boardString(cols []int) string {
+	var b strings.Builder
+	for _, col := range cols {
+		row := []byte(strings.Repeat(".", len(cols)))
+		row[col] = 'Q'
+		b.Write(row)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
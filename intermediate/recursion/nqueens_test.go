@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNQueensCounts(t *testing.T) {
+	// OEIS A000170, from n = 1
+	want := []int{1, 0, 0, 2, 10, 4, 40, 92, 352}
+	for i, count := range want {
+		n := i + 1
+		got := 0
+		for cols := range nQueens(n) {
+			got++
+			if !validQueens(cols) {
+				t.Fatalf("nQueens(%d) yielded an invalid board %v", n, cols)
+			}
+		}
+		if got != count {
+			t.Errorf("nQueens(%d) has %d solutions, want %d", n, got, count)
+		}
+	}
+}
+
+func TestNQueensNonPositive(t *testing.T) {
+	for _, n := range []int{0, -1, -100} {
+		if got := slices.Collect(nQueens(n)); len(got) != 0 {
+			t.Errorf("nQueens(%d) = %v, want nothing", n, got)
+		}
+	}
+}
+
+func TestNQueensStopsEarly(t *testing.T) {
+	var first [][]int
+	for cols := range nQueens(8) {
+		first = append(first, cols)
+		if len(first) == 3 {
+			break
+		}
+	}
+	// Each solution is a copy, so keeping it is safe
+	if len(first) != 3 || slices.Equal(first[0], first[1]) {
+		t.Errorf("first three solutions = %v", first)
+	}
+}
+
+// validQueens reports whether no two queens in cols attack each other.
+func validQueens(cols []int) bool {
+	for r1 := range cols {
+		for r2 := r1 + 1; r2 < len(cols); r2++ {
+			dc := cols[r2] - cols[r1]
+			if dc == 0 || dc == r2-r1 || dc == r1-r2 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestBoardString(t *testing.T) {
+	if got, want := boardString([]int{1, 3, 0, 2}), ".Q..\n...Q\nQ...\n..Q.\n"; got != want {
+		t.Errorf("boardString = %q, want %q", got, want)
+	}
+}
@@ -1,7 +1,79 @@
 package main
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
 func main() {
 	fmt.Println(factorial(5))
 	fmt.Println(sumOfNumbers(10))
+
+	// factorial(21) silently wraps around; the checked version says so
+	fmt.Println(factorial(21))
+	if _, err := factorialChecked(21); err != nil {
+		fmt.Println("factorialChecked(21):", err)
+	}
+
+	// math/big has no upper limit
+	f, _ := bigFactorial(30)
+	fmt.Println("30! =", f)
+	fib, _ := bigFibonacci(100)
+	fmt.Println("F(100) =", fib)
+	c, _ := bigBinomial(100, 50)
+	fmt.Println("100 choose 50 =", c)
+
+	// A million frames is fine for a loop or a formula
+	fmt.Println(sumOfNumbersIterative(1_000_000))
+	fmt.Println(sumOfNumbersFormula(1_000_000))
+
+	// Memoization turns exponential Fibonacci into linear Fibonacci
+	fmt.Println(fibonacci(25))
+	memoFib := newMemoFibonacci()
+	fmt.Println("F(200) =", memoFib.call(200))
+	stats := memoFib.snapshot()
+	fmt.Printf("cache: %d entries, %d hits, %d misses\n", memoFib.size(), stats.hits, stats.misses)
+
+	// The trampoline runs ten million "recursive" steps in constant stack space
+	fmt.Println(sumOfNumbersTrampolined(10_000_000))
+	fmt.Println("F(1000) has", len(fibonacciTrampolined(1000).String()), "digits")
+
+	for _, m := range hanoi(3, "A", "C", "B") {
+		fmt.Println(m)
+	}
+
+	for p := range permutations([]string{"a", "b", "c"}) {
+		fmt.Print(p, " ")
+	}
+	fmt.Println()
+	fmt.Println(slices.Collect(combinations([]int{1, 2, 3, 4}, 2)))
+	fmt.Println(slices.Collect(powerSet([]int{1, 2, 3})))
+
+	for solution := range nQueens(6) {
+		fmt.Print(boardString(solution))
+		break // just the first one
+	}
+	fmt.Println("8 queens has", len(slices.Collect(nQueens(8))), "solutions")
+
+	// Trace the calls factorial and sumOfNumbers make. Their bodies call
+	// self rather than themselves by name, so the tracer sees every level
+	factorialTrace := trace("factorial", factorialStep)
+	factorialTrace.call(4)
+	fmt.Print(factorialTrace)
+
+	sumTrace := trace("sumOfNumbers", sumOfNumbersStep)
+	sumTrace.call(3)
+	traceJSON, _ := json.MarshalIndent(sumTrace, "", "  ")
+	fmt.Println(string(traceJSON))
+
+	// Pascal's rule: C(n, k) = C(n-1, k-1) + C(n-1, k)
+	binomialTrace := trace2("binomial", func(self func(int, int) int, n, k int) int {
+		if k == 0 || k == n {
+			return 1
+		}
+		return self(n-1, k-1) + self(n-1, k)
+	})
+	binomialTrace.call(args2[int, int]{4, 2})
+	fmt.Print(binomialTrace)
 }
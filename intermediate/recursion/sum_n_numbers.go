@@ -1,9 +1,57 @@
 package main
 
+import "intermediate/generics/numeric"
+
 func sumOfNumbers(n int) int {
+	return sumOfNumbersStep(sumOfNumbers, n)
+}
+
+// sumOfNumbersStep is the body of sumOfNumbers in the same open form as
+// factorialStep
+func sumOfNumbersStep(self func(int) int, n int) int {
 	if n == 0 {
 		return 0
 	}
-	return n + sumOfNumbers(n-1)
+	return n + self(n-1)
 }
 
+// sumOfNumbersIterative is sumOfNumbers with a loop instead of recursion,
+// so it uses constant stack space however large n is
+func sumOfNumbersIterative(n int) int {
+	total := 0
+	for i := 1; i <= n; i++ {
+		total += i
+	}
+	return total
+}
+
+// sumOfNumbersTail is the tail-recursive form: the running total is passed
+// down as acc, so nothing is left to do after the recursive call returns.
+// Go does not eliminate tail calls, so this still uses n stack frames, but it
+// is the shape that converts mechanically into a loop or a trampoline.
+func sumOfNumbersTail(n, acc int) int {
+	if n <= 0 {
+		return acc
+	}
+	return sumOfNumbersTail(n-1, acc+n)
+}
+
+// sumOfNumbersFormula uses n(n+1)/2 and reports errOverflow instead of wrapping
+func sumOfNumbersFormula(n int) (int, error) {
+	if n < 0 {
+		return 0, errNegative
+	}
+	// Halve whichever factor is even before multiplying, so the division is
+	// exact and the product is as small as possible
+	a := n
+	b, err := numeric.AddChecked(n, 1)
+	if err != nil {
+		return 0, err
+	}
+	if a%2 == 0 {
+		a /= 2
+	} else {
+		b /= 2
+	}
+	return numeric.MulChecked(a, b)
+}
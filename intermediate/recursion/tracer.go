@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// traceCall records one call made through a tracer. The fields are exported
+// so the trace can be encoded as JSON.
+type traceCall[A, R any] struct {
+	Args     A                  `json:"args"`
+	Depth    int                `json:"depth"`
+	Result   R                  `json:"result"`
+	Duration time.Duration      `json:"duration_ns"`
+	Panicked bool               `json:"panicked,omitempty"` // Result is then the zero value
+	Calls    []*traceCall[A, R] `json:"calls,omitempty"`
+}
+
+// tracer records the call tree of a recursive function. The function is
+// written in "open" form: instead of calling itself by name it calls self,
+// which the tracer points back at its own recording wrapper. The plain
+// function is then just the open form applied to itself, as factorial is
+// to factorialStep, so the body is written once and can be traced as is.
+type tracer[A, R any] struct {
+	name  string
+	f     func(self func(A) R, args A) R
+	roots []*traceCall[A, R]
+	stack []*traceCall[A, R] // calls that have started but not returned
+}
+
+// trace wraps f so every call, including recursive ones, is recorded.
+// name is used when rendering the call tree.
+//
+//	t := trace("factorial", func(self func(int) int, n int) int {
+//		if n <= 1 {
+//			return 1
+//		}
+//		return n * self(n-1)
+//	})
+//	t.call(4)
+//	fmt.Print(t)
+func trace[A, R any](name string, f func(self func(A) R, args A) R) *tracer[A, R] {
+	return &tracer[A, R]{name: name, f: f}
+}
+
+// call runs the traced function on args and records the call
+func (t *tracer[A, R]) call(args A) R {
+	c := &traceCall[A, R]{Args: args, Depth: len(t.stack)}
+	if parent := len(t.stack) - 1; parent >= 0 {
+		t.stack[parent].Calls = append(t.stack[parent].Calls, c)
+	} else {
+		t.roots = append(t.roots, c)
+	}
+
+	t.stack = append(t.stack, c)
+	start := time.Now()
+	returned := false
+	// Deferred so that a panic, recovered further up, still leaves the
+	// stack as it was and later calls are recorded in the right place
+	defer func() {
+		c.Duration = time.Since(start)
+		c.Panicked = !returned
+		t.stack = t.stack[:len(t.stack)-1]
+	}()
+	c.Result = t.f(t.call, args)
+	returned = true
+	return c.Result
+}
+
+// String renders the recorded calls as an indented tree, one call per line
+func (t *tracer[A, R]) String() string {
+	var b strings.Builder
+	var write func(c *traceCall[A, R])
+	write = func(c *traceCall[A, R]) {
+		result := fmt.Sprintf("= %v", c.Result)
+		if c.Panicked {
+			result = "panicked"
+		}
+		fmt.Fprintf(&b, "%s%s(%v) %s  [%v]\n",
+			strings.Repeat("  ", c.Depth), t.name, c.Args, result, c.Duration)
+		for _, child := range c.Calls {
+			write(child)
+		}
+	}
+	for _, root := range t.roots {
+		write(root)
+	}
+	return b.String()
+}
+
+// MarshalJSON encodes the recorded call trees
+func (t *tracer[A, R]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name  string             `json:"name"`
+		Calls []*traceCall[A, R] `json:"calls"`
+	}{t.name, t.roots})
+}
+
+// args2 bundles the arguments of a two-argument function so it can be traced
+type args2[A, B any] struct {
+	First  A `json:"first"`
+	Second B `json:"second"`
+}
+
+func (a args2[A, B]) String() string {
+	return fmt.Sprintf("%v, %v", a.First, a.Second)
+}
+
+// trace2 is trace for functions of two arguments
+func trace2[A, B, R any](name string, f func(self func(A, B) R, a A, b B) R) *tracer[args2[A, B], R] {
+	return trace(name, func(self func(args2[A, B]) R, args args2[A, B]) R {
+		return f(func(a A, b B) R { return self(args2[A, B]{a, b}) }, args.First, args.Second)
+	})
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+// withoutTimes drops the durations from a rendered trace.
+func withoutTimes(s string) string {
+	return regexp.MustCompile(`  \[[^\]]*\]`).ReplaceAllString(s, "")
+}
+
+func TestTraceChain(t *testing.T) {
+	tr := trace("factorial", factorialStep)
+	if got := tr.call(4); got != factorial(4) {
+		t.Errorf("traced factorial(4) = %d, want %d", got, factorial(4))
+	}
+	want := "factorial(4) = 24\n" +
+		"  factorial(3) = 6\n" +
+		"    factorial(2) = 2\n" +
+		"      factorial(1) = 1\n"
+	if got := withoutTimes(tr.String()); got != want {
+		t.Errorf("trace:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTraceTree(t *testing.T) {
+	tr := trace2("binomial", func(self func(int, int) int, n, k int) int {
+		if k == 0 || k == n {
+			return 1
+		}
+		return self(n-1, k-1) + self(n-1, k)
+	})
+	if got := tr.call(args2[int, int]{4, 2}); got != 6 {
+		t.Fatalf("binomial(4, 2) = %d", got)
+	}
+	if len(tr.roots) != 1 {
+		t.Fatalf("%d roots, want 1", len(tr.roots))
+	}
+
+	// Every call has two children unless it is a base case, sits one level
+	// below its parent, and returns the sum of its children
+	var nodes, leaves int
+	var check func(c *traceCall[args2[int, int], int], depth int)
+	check = func(c *traceCall[args2[int, int], int], depth int) {
+		nodes++
+		if c.Depth != depth {
+			t.Errorf("%v at depth %d, want %d", c.Args, c.Depth, depth)
+		}
+		base := c.Args.Second == 0 || c.Args.Second == c.Args.First
+		switch {
+		case base && len(c.Calls) == 0:
+			leaves++
+		case !base && len(c.Calls) == 2:
+			if c.Calls[0].Result+c.Calls[1].Result != c.Result {
+				t.Errorf("%v = %d, but its children sum to %d", c.Args, c.Result, c.Calls[0].Result+c.Calls[1].Result)
+			}
+			for _, child := range c.Calls {
+				check(child, depth+1)
+			}
+		default:
+			t.Errorf("%v has %d children", c.Args, len(c.Calls))
+		}
+	}
+	check(tr.roots[0], 0)
+	// One leaf per path in Pascal's triangle, so C(4, 2) of them
+	if nodes != 11 || leaves != 6 {
+		t.Errorf("%d calls with %d leaves, want 11 and 6", nodes, leaves)
+	}
+
+	// A second top-level call starts a new root
+	tr.call(args2[int, int]{1, 1})
+	if len(tr.roots) != 2 || tr.roots[1].Depth != 0 {
+		t.Errorf("second call: %d roots", len(tr.roots))
+	}
+}
+
+func TestTraceJSON(t *testing.T) {
+	tr := trace("sumOfNumbers", sumOfNumbersStep)
+	tr.call(2)
+	data, err := json.Marshal(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type call struct {
+		Args     int     `json:"args"`
+		Depth    int     `json:"depth"`
+		Result   int     `json:"result"`
+		Duration *int64  `json:"duration_ns"`
+		Panicked bool    `json:"panicked"`
+		Calls    []*call `json:"calls"`
+	}
+	var got struct {
+		Name  string  `json:"name"`
+		Calls []*call `json:"calls"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "sumOfNumbers" || len(got.Calls) != 1 {
+		t.Fatalf("decoded %s", data)
+	}
+	c := got.Calls[0]
+	for depth, want := range []struct{ args, result int }{{2, 3}, {1, 1}, {0, 0}} {
+		if c == nil || c.Args != want.args || c.Result != want.result || c.Depth != depth || c.Duration == nil {
+			t.Fatalf("depth %d: %+v, want args %d result %d, in %s", depth, c, want.args, want.result, data)
+		}
+		if len(c.Calls) > 0 {
+			c = c.Calls[0]
+		} else {
+			c = nil
+		}
+	}
+	if regexp.MustCompile(`"panicked"|"calls":null`).Match(data) {
+		t.Errorf("empty fields should be omitted: %s", data)
+	}
+}
+
+func TestTracePanic(t *testing.T) {
+	tr := trace("countdown", func(self func(int) int, n int) int {
+		if n == 2 {
+			panic("boom")
+		}
+		if n == 0 {
+			return 0
+		}
+		return self(n - 1)
+	})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("the panic was swallowed")
+			}
+		}()
+		tr.call(4)
+	}()
+	if len(tr.stack) != 0 {
+		t.Fatalf("%d calls still open after the panic", len(tr.stack))
+	}
+
+	// The next call is a new root at depth 0, not a child of the call that
+	// panicked
+	if got := tr.call(1); got != 0 {
+		t.Errorf("countdown(1) = %d", got)
+	}
+	want := "countdown(4) panicked\n" +
+		"  countdown(3) panicked\n" +
+		"    countdown(2) panicked\n" +
+		"countdown(1) = 0\n" +
+		"  countdown(0) = 0\n"
+	if got := withoutTimes(tr.String()); got != want {
+		t.Errorf("trace:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+// thunk is one step of a trampolined computation: either the final value, or
+// a function that performs the next step
+type thunk[T any] struct {
+	value T
+	next  func() thunk[T]
+}
+
+// done ends a trampolined computation with value
+func done[T any](value T) thunk[T] {
+	return thunk[T]{value: value}
+}
+
+// more continues a trampolined computation with next
+func more[T any](next func() thunk[T]) thunk[T] {
+	return thunk[T]{next: next}
+}
+
+// trampoline runs t to completion. A tail-recursive function that returns
+// more(...) instead of calling itself directly is driven here by a loop, so
+// it runs in constant stack space however deep the "recursion" goes.
+func trampoline[T any](t thunk[T]) T {
+	for t.next != nil {
+		t = t.next()
+	}
+	return t.value
+}
+
+// sumOfNumbersTrampolined is sumOfNumbersTail rewritten to run on a trampoline
+func sumOfNumbersTrampolined(n int) int {
+	var step func(n, acc int) thunk[int]
+	step = func(n, acc int) thunk[int] {
+		if n <= 0 {
+			return done(acc)
+		}
+		return more(func() thunk[int] { return step(n-1, acc+n) })
+	}
+	return trampoline(step(n, 0))
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestTrampoline(t *testing.T) {
+	for _, n := range []int{-5, 0, 1, 10, 100} {
+		if got, want := sumOfNumbersTrampolined(n), sumOfNumbersIterative(n); got != want {
+			t.Errorf("sumOfNumbersTrampolined(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestTrampolineDeep(t *testing.T) {
+	// sumOfNumbersTail would need hundreds of megabytes of stack for this;
+	// the trampoline needs one frame
+	const n = 10_000_000
+	if got := sumOfNumbersTrampolined(n); got != n*(n+1)/2 {
+		t.Errorf("sumOfNumbersTrampolined(%d) = %d", n, got)
+	}
+
+	// Mutual recursion works the same way
+	var isEven, isOdd func(n int) thunk[bool]
+	isEven = func(n int) thunk[bool] {
+		if n == 0 {
+			return done(true)
+		}
+		return more(func() thunk[bool] { return isOdd(n - 1) })
+	}
+	isOdd = func(n int) thunk[bool] {
+		if n == 0 {
+			return done(false)
+		}
+		return more(func() thunk[bool] { return isEven(n - 1) })
+	}
+	if !trampoline(isEven(n)) || trampoline(isOdd(n)) {
+		t.Errorf("isEven(%d) is wrong", n)
+	}
+}
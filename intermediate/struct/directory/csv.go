@@ -0,0 +1,87 @@
+package directory
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"intermediate/struct/model"
+)
+
+// csvHeader is the first row written by ExportCSV and expected by ImportCSV.
+var csvHeader = []string{"name", "age", "city", "country"}
+
+// ImportCSV reads employees from r and adds them all, or none if any row is
+// invalid. The first row must be the header name,age,city,country. Spaces
+// around fields are ignored. It returns the number of employees added.
+func (d *Directory) ImportCSV(r io.Reader) (int, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, errors.New("directory: empty CSV")
+		}
+		return 0, fmt.Errorf("directory: %w", err)
+	}
+	for i := range header {
+		header[i] = fold(header[i])
+	}
+	if !slices.Equal(header, csvHeader) {
+		return 0, fmt.Errorf("directory: CSV header is %v, want %v", header, csvHeader)
+	}
+
+	// Parse and validate everything before touching the directory
+	var employees []model.Employee
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("directory: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+		age, err := strconv.Atoi(row[1])
+		if err != nil {
+			return 0, fmt.Errorf("directory: line %d: age %q is not a number", line, row[1])
+		}
+		e := model.Employee{Name: row[0], Age: age, Address: model.Address{City: row[2], Country: row[3]}}
+		if err := e.Validate(); err != nil {
+			return 0, fmt.Errorf("directory: line %d: %w", line, err)
+		}
+		employees = append(employees, e)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range employees {
+		d.insert(e)
+	}
+	return len(employees), nil
+}
+
+// ExportCSV writes every employee to w in ID order, with a header row.
+func (d *Directory) ExportCSV(w io.Writer) error {
+	rows := d.Query().All()
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write([]string{r.Name, strconv.Itoa(r.Age), r.City, r.Country}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,72 @@
+package directory
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	d := sample(t)
+	var buf bytes.Buffer
+	if err := d.ExportCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "name,age,city,country\nAnn,41,London,UK\n") {
+		t.Errorf("export starts:\n%s", buf.String())
+	}
+
+	back := New()
+	n, err := back.ImportCSV(&buf)
+	if err != nil || n != d.Len() {
+		t.Fatalf("ImportCSV = %d, %v", n, err)
+	}
+	// The city " London " is stored trimmed on the way back
+	for _, r := range d.Query().All() {
+		got, err := back.Get(r.ID)
+		want := r.Employee
+		want.City = strings.TrimSpace(want.City)
+		if err != nil || got != want {
+			t.Errorf("ID %d: got %+v, want %+v", r.ID, got, want)
+		}
+	}
+}
+
+func TestImportCSVTrims(t *testing.T) {
+	d := New()
+	in := " Name , AGE ,city,country\n" +
+		"Ann , 30 , London , UK \n" +
+		"\"Ben\",\"29 \",Paris,France\n"
+	if n, err := d.ImportCSV(strings.NewReader(in)); err != nil || n != 2 {
+		t.Fatalf("ImportCSV = %d, %v", n, err)
+	}
+	if e, _ := d.Get(1); e.Name != "Ann" || e.Age != 30 || e.City != "London" || e.Country != "UK" {
+		t.Errorf("first row = %+v", e)
+	}
+	if e, _ := d.Get(2); e.Age != 29 {
+		t.Errorf("second row = %+v", e)
+	}
+}
+
+func TestImportCSVErrors(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"empty", "", "empty CSV"},
+		{"bad header", "name,age,town,country\n", "header"},
+		{"bad age", "name,age,city,country\nAnn,41,London,UK\nBen,old,Paris,France\n", `line 3: age "old"`},
+		{"invalid employee", "name,age,city,country\nAnn,41,London,UK\n,200,Paris,France\n", "line 3"},
+		{"missing field", "name,age,city,country\nAnn,41,London\n", "wrong number of fields"},
+	}
+	for _, tt := range tests {
+		d := sample(t)
+		n, err := d.ImportCSV(strings.NewReader(tt.in))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want one mentioning %q", tt.name, err, tt.want)
+		}
+		// All or nothing: the good rows before the bad one are not added
+		if n != 0 || d.Len() != 6 {
+			t.Errorf("%s: added %d, Len %d", tt.name, n, d.Len())
+		}
+	}
+}
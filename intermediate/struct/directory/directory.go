@@ -0,0 +1,158 @@
+// Package directory is an in-memory store of employees with secondary
+// indexes on city, country and age, a small query builder, and CSV import
+// and export. It is safe for concurrent use: any number of readers can query
+// at once while writers take turns.
+package directory
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"intermediate/struct/model"
+)
+
+// ID identifies an employee within a Directory.
+type ID int
+
+// ErrNotFound is returned when no employee has the requested ID.
+var ErrNotFound = errors.New("directory: employee not found")
+
+// index maps a key to the set of IDs that have it.
+type index[K comparable] map[K]map[ID]struct{}
+
+func (ix index[K]) add(k K, id ID) {
+	if ix[k] == nil {
+		ix[k] = make(map[ID]struct{})
+	}
+	ix[k][id] = struct{}{}
+}
+
+func (ix index[K]) remove(k K, id ID) {
+	delete(ix[k], id)
+	if len(ix[k]) == 0 {
+		delete(ix, k)
+	}
+}
+
+// Directory stores employees. The zero value is not usable; call New.
+type Directory struct {
+	mu        sync.RWMutex
+	nextID    ID
+	employees map[ID]model.Employee
+	byCity    index[string] // keys are lower case, so lookups ignore case
+	byCountry index[string]
+	byAge     index[int]
+}
+
+// New returns an empty Directory.
+func New() *Directory {
+	return &Directory{
+		nextID:    1,
+		employees: make(map[ID]model.Employee),
+		byCity:    make(index[string]),
+		byCountry: make(index[string]),
+		byAge:     make(index[int]),
+	}
+}
+
+// fold normalizes an index key.
+func fold(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Add validates e, stores it and returns its new ID.
+func (d *Directory) Add(e model.Employee) (ID, error) {
+	if err := e.Validate(); err != nil {
+		return 0, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.insert(e), nil
+}
+
+// insert stores e, which must be valid. The caller holds the write lock.
+func (d *Directory) insert(e model.Employee) ID {
+	id := d.nextID
+	d.nextID++
+	d.employees[id] = e
+	d.indexAdd(id, e)
+	return id
+}
+
+func (d *Directory) indexAdd(id ID, e model.Employee) {
+	d.byCity.add(fold(e.City), id)
+	d.byCountry.add(fold(e.Country), id)
+	d.byAge.add(e.Age, id)
+}
+
+func (d *Directory) indexRemove(id ID, e model.Employee) {
+	d.byCity.remove(fold(e.City), id)
+	d.byCountry.remove(fold(e.Country), id)
+	d.byAge.remove(e.Age, id)
+}
+
+// Get returns the employee with the given ID.
+func (d *Directory) Get(id ID) (model.Employee, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	e, ok := d.employees[id]
+	if !ok {
+		return model.Employee{}, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	return e, nil
+}
+
+// Update validates e and replaces the employee with the given ID.
+func (d *Directory) Update(id ID, e model.Employee) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	old, ok := d.employees[id]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	d.indexRemove(id, old)
+	d.employees[id] = e
+	d.indexAdd(id, e)
+	return nil
+}
+
+// Remove deletes the employee with the given ID.
+func (d *Directory) Remove(id ID) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e, ok := d.employees[id]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	d.indexRemove(id, e)
+	delete(d.employees, id)
+	return nil
+}
+
+// Len returns the number of employees stored.
+func (d *Directory) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.employees)
+}
+
+// Cities returns the distinct cities in the directory, in lower case and sorted.
+func (d *Directory) Cities() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return slices.Sorted(maps.Keys(d.byCity))
+}
+
+// Countries returns the distinct countries in the directory, in lower case and sorted.
+func (d *Directory) Countries() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return slices.Sorted(maps.Keys(d.byCountry))
+}
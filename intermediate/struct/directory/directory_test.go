@@ -0,0 +1,152 @@
+package directory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"intermediate/struct/model"
+)
+
+func employee(name string, age int, city, country string) model.Employee {
+	return model.Employee{Name: name, Age: age, Address: model.Address{City: city, Country: country}}
+}
+
+// sample returns a directory of six employees, with IDs 1 to 6 in order.
+func sample(t *testing.T) *Directory {
+	t.Helper()
+	d := New()
+	for _, e := range []model.Employee{
+		employee("Ann", 41, "London", "UK"),
+		employee("ben", 29, "london", "uk"),
+		employee("Cleo", 35, "Paris", "France"),
+		employee("Dev", 29, "Lyon", "France"),
+		employee("Eve", 52, " London ", "UK"),
+		employee("Finn", 23, "Dublin", "Ireland"),
+	} {
+		if _, err := d.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return d
+}
+
+func TestAddGet(t *testing.T) {
+	d := New()
+	id, err := d.Add(employee("Ann", 41, "London", "UK"))
+	if err != nil || id != 1 {
+		t.Fatalf("Add = %d, %v", id, err)
+	}
+	if e, err := d.Get(id); err != nil || e.Name != "Ann" {
+		t.Errorf("Get = %+v, %v", e, err)
+	}
+	if _, err := d.Get(99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(99) error = %v, want ErrNotFound", err)
+	}
+
+	var fe *model.FieldError
+	if _, err := d.Add(employee("", -1, "", "UK")); !errors.As(err, &fe) {
+		t.Errorf("Add of an invalid employee: %v", err)
+	}
+	if d.Len() != 1 {
+		t.Errorf("Len = %d after a failed Add", d.Len())
+	}
+}
+
+func TestIndexesFollowChanges(t *testing.T) {
+	d := sample(t)
+	if got, want := d.Cities(), []string{"dublin", "london", "lyon", "paris"}; !slices.Equal(got, want) {
+		t.Errorf("Cities = %v, want %v", got, want)
+	}
+
+	// Moving Finn out of Dublin empties its index entry
+	if err := d.Update(6, employee("Finn", 24, "Paris", "France")); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Cities(); slices.Contains(got, "dublin") {
+		t.Errorf("Cities after Update = %v", got)
+	}
+	if got := d.Countries(); !slices.Equal(got, []string{"france", "uk"}) {
+		t.Errorf("Countries after Update = %v", got)
+	}
+	if n := d.Where(AgeBetween(23, 23)).Count(); n != 0 {
+		t.Errorf("the old age is still indexed: %d", n)
+	}
+	if n := d.Where(City("paris")).AndAgeBetween(24, 24).Count(); n != 1 {
+		t.Errorf("the new city and age are not indexed: %d", n)
+	}
+
+	if err := d.Remove(3); err != nil {
+		t.Fatal(err)
+	}
+	if n := d.Where(City("Paris")).Count(); n != 1 {
+		t.Errorf("Paris has %d employees after Remove, want 1", n)
+	}
+	if err := d.Remove(3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Remove error = %v, want ErrNotFound", err)
+	}
+	if err := d.Update(3, employee("X", 1, "A", "B")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update of a removed ID error = %v, want ErrNotFound", err)
+	}
+	if err := d.Update(1, employee("Ann", 400, "London", "UK")); err == nil {
+		t.Error("Update with an invalid employee succeeded")
+	}
+	if e, _ := d.Get(1); e.Age != 41 {
+		t.Errorf("a failed Update changed the employee to %+v", e)
+	}
+}
+
+func TestIDsAreNotReused(t *testing.T) {
+	d := New()
+	a, _ := d.Add(employee("A", 1, "X", "Y"))
+	d.Remove(a)
+	if b, _ := d.Add(employee("B", 1, "X", "Y")); b == a {
+		t.Errorf("ID %d was reused", b)
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	d := sample(t)
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				id, err := d.Add(employee(fmt.Sprintf("w%d-%d", w, i), i%100, "City"+fmt.Sprint(i%5), "Land"))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if i%3 == 0 {
+					d.Update(id, employee("moved", 50, "Elsewhere", "Land"))
+				}
+				if i%7 == 0 {
+					d.Remove(id)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				d.Where(Country("land")).AndAgeBetween(10, 60).OrderBy(ByName).Limit(5).All()
+				d.Cities()
+				d.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 4 workers each removed the 29 of their 200 whose i is a multiple of 7
+	if want := 6 + 4*(200-29); d.Len() != want {
+		t.Errorf("Len = %d, want %d", d.Len(), want)
+	}
+	if got, want := d.Where(Country("Land")).Count(), 4*(200-29); got != want {
+		t.Errorf("Country(Land) = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,223 @@
+package directory
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	"intermediate/struct/model"
+)
+
+// Condition is one filter in a query. Conditions backed by an index let the
+// query start from a small set of candidates instead of scanning everything.
+type Condition struct {
+	match      func(model.Employee) bool
+	candidates func(d *Directory) map[ID]struct{} // nil if no index applies
+}
+
+// City matches employees in city, ignoring case.
+func City(city string) Condition {
+	key := fold(city)
+	return Condition{
+		match:      func(e model.Employee) bool { return fold(e.City) == key },
+		candidates: func(d *Directory) map[ID]struct{} { return d.byCity[key] },
+	}
+}
+
+// Country matches employees in country, ignoring case.
+func Country(country string) Condition {
+	key := fold(country)
+	return Condition{
+		match:      func(e model.Employee) bool { return fold(e.Country) == key },
+		candidates: func(d *Directory) map[ID]struct{} { return d.byCountry[key] },
+	}
+}
+
+// AgeBetween matches employees aged from lo to hi inclusive.
+func AgeBetween(lo, hi int) Condition {
+	return Condition{
+		match: func(e model.Employee) bool { return e.Age >= lo && e.Age <= hi },
+		candidates: func(d *Directory) map[ID]struct{} {
+			ids := make(map[ID]struct{})
+			for age := max(lo, model.MinAge); age <= min(hi, model.MaxAge); age++ {
+				maps.Copy(ids, d.byAge[age])
+			}
+			return ids
+		},
+	}
+}
+
+// NameContains matches employees whose name contains s, ignoring case.
+// There is no index on names, so on its own it scans every employee.
+func NameContains(s string) Condition {
+	s = strings.ToLower(s)
+	return Condition{match: func(e model.Employee) bool {
+		return strings.Contains(strings.ToLower(e.Name), s)
+	}}
+}
+
+// Field names something a query can be ordered by.
+type Field int
+
+// Names, cities and countries are compared ignoring case, as City and
+// Country match them.
+const (
+	ByID Field = iota
+	ByName
+	ByAge
+	ByCity
+	ByCountry
+)
+
+// Result is an employee returned by a query, together with its ID.
+type Result struct {
+	ID ID
+	model.Employee
+}
+
+// Query is built by chaining conditions and options, then run with All or
+// Count. Every method returns a new Query and leaves its receiver alone, so
+// one partial query can be the base of several others. Building a query
+// does not lock the directory; running it takes a read lock, so queries run
+// concurrently with each other.
+//
+//	d.Where(directory.City("London")).AndAgeBetween(30, 50).OrderBy(directory.ByName).All()
+type Query struct {
+	d          *Directory
+	conditions []Condition
+	order      Field
+	desc       bool
+	limit      int
+}
+
+// Where starts a query with cond.
+func (d *Directory) Where(cond Condition) *Query {
+	return &Query{d: d, conditions: []Condition{cond}}
+}
+
+// Query starts a query that matches every employee.
+func (d *Directory) Query() *Query {
+	return &Query{d: d}
+}
+
+// with returns a copy of q changed by f. The copy's conditions are clipped,
+// so appending to them never writes into q's.
+func (q *Query) with(f func(*Query)) *Query {
+	c := *q
+	c.conditions = slices.Clip(c.conditions)
+	f(&c)
+	return &c
+}
+
+// And narrows the query with another condition.
+func (q *Query) And(cond Condition) *Query {
+	return q.with(func(c *Query) { c.conditions = append(c.conditions, cond) })
+}
+
+// AndCity is And(City(city)).
+func (q *Query) AndCity(city string) *Query {
+	return q.And(City(city))
+}
+
+// AndCountry is And(Country(country)).
+func (q *Query) AndCountry(country string) *Query {
+	return q.And(Country(country))
+}
+
+// AndAgeBetween is And(AgeBetween(lo, hi)).
+func (q *Query) AndAgeBetween(lo, hi int) *Query {
+	return q.And(AgeBetween(lo, hi))
+}
+
+// OrderBy sorts results by f, ascending. Ties are broken by ID.
+func (q *Query) OrderBy(f Field) *Query {
+	return q.with(func(c *Query) { c.order, c.desc = f, false })
+}
+
+// OrderByDesc sorts results by f, descending. Ties are broken by ID.
+func (q *Query) OrderByDesc(f Field) *Query {
+	return q.with(func(c *Query) { c.order, c.desc = f, true })
+}
+
+// Limit caps the number of results; 0 means no limit.
+func (q *Query) Limit(n int) *Query {
+	return q.with(func(c *Query) { c.limit = n })
+}
+
+// All runs the query and returns the matching employees.
+func (q *Query) All() []Result {
+	q.d.mu.RLock()
+	defer q.d.mu.RUnlock()
+
+	results := q.matches()
+	slices.SortFunc(results, func(a, b Result) int {
+		c := q.compare(a, b)
+		if q.desc {
+			c = -c
+		}
+		return cmp.Or(c, cmp.Compare(a.ID, b.ID))
+	})
+	if q.limit > 0 && len(results) > q.limit {
+		results = results[:q.limit]
+	}
+	return results
+}
+
+// Count runs the query and returns the number of matches, ignoring Limit.
+func (q *Query) Count() int {
+	q.d.mu.RLock()
+	defer q.d.mu.RUnlock()
+	return len(q.matches())
+}
+
+// matches returns every employee satisfying all conditions, unsorted.
+// The caller holds the read lock.
+func (q *Query) matches() []Result {
+	// Start from the smallest candidate set any index offers, then check
+	// every condition against each candidate
+	var candidates map[ID]struct{}
+	indexed := false
+	for _, c := range q.conditions {
+		if c.candidates == nil {
+			continue
+		}
+		if ids := c.candidates(q.d); !indexed || len(ids) < len(candidates) {
+			candidates, indexed = ids, true
+		}
+	}
+
+	var results []Result
+	check := func(id ID, e model.Employee) {
+		for _, c := range q.conditions {
+			if !c.match(e) {
+				return
+			}
+		}
+		results = append(results, Result{id, e})
+	}
+	if indexed {
+		for id := range candidates {
+			check(id, q.d.employees[id])
+		}
+	} else {
+		for id, e := range q.d.employees {
+			check(id, e)
+		}
+	}
+	return results
+}
+
+func (q *Query) compare(a, b Result) int {
+	switch q.order {
+	case ByName:
+		return cmp.Compare(fold(a.Name), fold(b.Name))
+	case ByAge:
+		return cmp.Compare(a.Age, b.Age)
+	case ByCity:
+		return cmp.Compare(fold(a.City), fold(b.City))
+	case ByCountry:
+		return cmp.Compare(fold(a.Country), fold(b.Country))
+	}
+	return 0 // ByID: the tie-breaker does the work
+}
@@ -0,0 +1,107 @@
+package directory
+
+import (
+	"slices"
+	"testing"
+)
+
+func ids(rs []Result) []ID {
+	out := make([]ID, len(rs))
+	for i, r := range rs {
+		out[i] = r.ID
+	}
+	return out
+}
+
+func TestQuery(t *testing.T) {
+	d := sample(t)
+	tests := []struct {
+		name string
+		q    *Query
+		want []ID
+	}{
+		{"everything", d.Query(), []ID{1, 2, 3, 4, 5, 6}},
+		{"city ignores case and spaces", d.Where(City("LONDON")), []ID{1, 2, 5}},
+		{"country", d.Where(Country("france")), []ID{3, 4}},
+		{"age range", d.Where(AgeBetween(29, 35)), []ID{2, 3, 4}},
+		{"age range past the limits", d.Where(AgeBetween(-10, 1000)), []ID{1, 2, 3, 4, 5, 6}},
+		{"empty age range", d.Where(AgeBetween(40, 30)), nil},
+		{"name", d.Where(NameContains("E")), []ID{2, 3, 4, 5}},
+		{"combined", d.Where(Country("UK")).AndAgeBetween(30, 60).AndCity("london"), []ID{1, 5}},
+		{"unindexed and indexed", d.Query().And(NameContains("n")).AndCountry("uk"), []ID{1, 2}},
+		{"no match", d.Where(City("Rome")), nil},
+		{"by name ignores case", d.Query().OrderBy(ByName), []ID{1, 2, 3, 4, 5, 6}},
+		{"by name, descending", d.Query().OrderByDesc(ByName), []ID{6, 5, 4, 3, 2, 1}},
+		{"by age, ties by ID", d.Query().OrderBy(ByAge), []ID{6, 2, 4, 3, 1, 5}},
+		{"by age descending, ties by ID", d.Query().OrderByDesc(ByAge), []ID{5, 1, 3, 2, 4, 6}},
+		{"by city", d.Query().OrderBy(ByCity), []ID{6, 1, 2, 5, 4, 3}},
+		{"by country", d.Query().OrderBy(ByCountry), []ID{3, 4, 6, 1, 2, 5}},
+		{"limit", d.Query().OrderBy(ByAge).Limit(2), []ID{6, 2}},
+		{"limit above the count", d.Where(Country("france")).Limit(10), []ID{3, 4}},
+	}
+	for _, tt := range tests {
+		got := ids(tt.q.All())
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		if n := tt.q.Count(); tt.q.limit == 0 && n != len(tt.want) {
+			t.Errorf("%s: Count = %d, want %d", tt.name, n, len(tt.want))
+		}
+	}
+	if n := d.Query().Limit(1).Count(); n != 6 {
+		t.Errorf("Count with a limit = %d, want 6", n)
+	}
+}
+
+func TestQueryIsImmutable(t *testing.T) {
+	d := sample(t)
+	base := d.Where(Country("UK"))
+	// Give base spare capacity, as an append would, to catch sharing
+	base = base.And(AgeBetween(0, 150))
+
+	young := base.AndAgeBetween(0, 30)
+	old := base.AndAgeBetween(50, 150)
+	limited := base.OrderByDesc(ByAge).Limit(1)
+
+	if got := ids(young.All()); !slices.Equal(got, []ID{2}) {
+		t.Errorf("young = %v, want [2]", got)
+	}
+	if got := ids(old.All()); !slices.Equal(got, []ID{5}) {
+		t.Errorf("old = %v, want [5]", got)
+	}
+	if got := ids(limited.All()); !slices.Equal(got, []ID{5}) {
+		t.Errorf("limited = %v, want [5]", got)
+	}
+	if got := ids(base.All()); !slices.Equal(got, []ID{1, 2, 5}) {
+		t.Errorf("base = %v, want [1 2 5]", got)
+	}
+}
+
+func TestQueryMatchesScan(t *testing.T) {
+	// Whichever index a query starts from, it finds what a full scan finds
+	d := sample(t)
+	conds := []Condition{City("london"), Country("UK"), AgeBetween(25, 45), NameContains("e")}
+	for mask := 1; mask < 1<<len(conds); mask++ {
+		q := d.Query()
+		var scan []ID
+		for i, c := range conds {
+			if mask&(1<<i) != 0 {
+				q = q.And(c)
+			}
+		}
+		for _, r := range d.Query().All() {
+			ok := true
+			for i, c := range conds {
+				if mask&(1<<i) != 0 && !c.match(r.Employee) {
+					ok = false
+				}
+			}
+			if ok {
+				scan = append(scan, r.ID)
+			}
+		}
+		if got := ids(q.All()); !slices.Equal(got, scan) {
+			t.Errorf("conditions %04b: got %v, scan finds %v", mask, got, scan)
+		}
+	}
+}
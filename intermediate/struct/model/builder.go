@@ -0,0 +1,75 @@
+package model
+
+import "slices"
+
+// Builder is a fluent alternative to passing options to the constructors:
+//
+//	rob, err := model.NewBuilder("Rob").
+//		Age(25).
+//		Address("New York", "USA").
+//		Cell("987-654-3210").
+//		Person2()
+//
+// Every method returns a new Builder and leaves its receiver untouched, so
+// a partly configured Builder can be shared as a template. Nothing is
+// validated until one of Person, Person2 or Employee is called. Those start
+// from the same defaults as the constructors, and each returns a new value
+// that shares nothing with the Builder, but whose fields, as the package
+// doc explains, are not locked against change.
+type Builder struct {
+	name string
+	opts []Option
+}
+
+// NewBuilder starts building a value with the given name.
+func NewBuilder(name string) Builder {
+	return Builder{name: name}
+}
+
+// with returns a copy of b with opt appended. Clipping the slice first
+// forces append to copy, so builders never share a backing array.
+func (b Builder) with(opt Option) Builder {
+	b.opts = append(slices.Clip(b.opts), opt)
+	return b
+}
+
+// Name replaces the name.
+func (b Builder) Name(name string) Builder {
+	b.name = name
+	return b
+}
+
+// Age sets the age.
+func (b Builder) Age(age int) Builder {
+	return b.with(WithAge(age))
+}
+
+// Address sets the address.
+func (b Builder) Address(city, country string) Builder {
+	return b.with(WithAddress(Address{City: city, Country: country}))
+}
+
+// Home sets the home phone number.
+func (b Builder) Home(home string) Builder {
+	return b.with(WithHome(home))
+}
+
+// Cell sets the cell phone number.
+func (b Builder) Cell(cell string) Builder {
+	return b.with(WithCell(cell))
+}
+
+// Person builds a validated Person.
+func (b Builder) Person() (Person, error) {
+	return NewPerson(b.name, b.opts...)
+}
+
+// Person2 builds a validated Person2.
+func (b Builder) Person2() (Person2, error) {
+	return NewPerson2(b.name, b.opts...)
+}
+
+// Employee builds a validated Employee.
+func (b Builder) Employee() (Employee, error) {
+	return NewEmployee(b.name, b.opts...)
+}
@@ -0,0 +1,76 @@
+// Package model holds exported versions of the structs from the struct
+// lesson, with encoding tags, validation and constructors that refuse to
+// build invalid values.
+//
+// The validate tags mirror the Validate methods, so the same rules can also
+// be checked generically with the validate package.
+//
+// The types are plain structs with exported fields, so that encoders can
+// see them. A constructor only guarantees that the value it returns is
+// valid; a field changed afterwards is not checked until Validate is
+// called again.
+package model
+
+// Age limits accepted by Validate and the "age" rule.
+const (
+	MinAge = 0
+	MaxAge = 150
+)
+
+// Person is a person with a name and an age.
+type Person struct {
+	Name string `json:"name" yaml:"name" validate:"required"`
+	Age  int    `json:"age" yaml:"age" validate:"age"`
+}
+
+// Address is a physical address.
+type Address struct {
+	City    string `json:"city" yaml:"city" validate:"required"`
+	Country string `json:"country" yaml:"country" validate:"required"`
+}
+
+// PhoneHomeCell holds home and cell phone numbers. Either may be empty.
+type PhoneHomeCell struct {
+	Home string `json:"home,omitempty" yaml:"home,omitempty" validate:"omitempty,phone"`
+	Cell string `json:"cell,omitempty" yaml:"cell,omitempty" validate:"omitempty,phone"`
+}
+
+// Person2 is a person with an address and phone numbers held in named
+// fields. Unlike the lesson's version, Address is exported so encoders see it.
+type Person2 struct {
+	Name          string        `json:"name" yaml:"name" validate:"required"`
+	Age           int           `json:"age" yaml:"age" validate:"age"`
+	Address       Address       `json:"address" yaml:"address"`
+	PhoneHomeCell PhoneHomeCell `json:"phone" yaml:"phone"`
+}
+
+// Employee embeds Address, so City and Country are promoted to Employee and
+// appear at the top level of its JSON.
+type Employee struct {
+	Name    string `json:"name" yaml:"name" validate:"required"`
+	Age     int    `json:"age" yaml:"age" validate:"age"`
+	Address `yaml:",inline"`
+}
+
+// NewAddress returns a validated Address.
+func NewAddress(city, country string) (Address, error) {
+	a := Address{City: city, Country: country}
+	if err := a.Validate(); err != nil {
+		return Address{}, err
+	}
+	return a, nil
+}
+
+// NewPhoneHomeCell returns validated phone numbers.
+func NewPhoneHomeCell(home, cell string) (PhoneHomeCell, error) {
+	p := PhoneHomeCell{Home: home, Cell: cell}
+	if err := p.Validate(); err != nil {
+		return PhoneHomeCell{}, err
+	}
+	return p, nil
+}
+
+// Greet returns a greeting message from the person.
+func (p Person) Greet() string {
+	return p.Name + " says hello!"
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// settings collects what the options passed to a constructor ask for.
+type settings struct {
+	age     int
+	address Address
+	phone   PhoneHomeCell
+	used    []string // names of the options applied, in order
+}
+
+// DefaultAge is the age of a value built without WithAge.
+const DefaultAge = 18
+
+// defaults returns the settings every constructor starts from, before its
+// options are applied. Anything without a default keeps its zero value,
+// which means no phone numbers and an address that fails validation.
+func defaults() settings {
+	return settings{age: DefaultAge}
+}
+
+// Option configures a value built by NewPerson, NewPerson2 or NewEmployee,
+// overriding the defaults.
+type Option func(*settings)
+
+// WithAge sets the age.
+func WithAge(age int) Option {
+	return func(s *settings) {
+		s.age = age
+		s.used = append(s.used, "age")
+	}
+}
+
+// WithAddress sets the address.
+func WithAddress(a Address) Option {
+	return func(s *settings) {
+		s.address = a
+		s.used = append(s.used, "address")
+	}
+}
+
+// WithHome sets the home phone number.
+func WithHome(home string) Option {
+	return func(s *settings) {
+		s.phone.Home = home
+		s.used = append(s.used, "home")
+	}
+}
+
+// WithCell sets the cell phone number.
+func WithCell(cell string) Option {
+	return func(s *settings) {
+		s.phone.Cell = cell
+		s.used = append(s.used, "cell")
+	}
+}
+
+// apply runs opts over the defaults and reports any that the type being
+// built has no field for, such as WithCell on an Employee.
+func apply(typeName string, allowed map[string]bool, opts []Option) (settings, error) {
+	s := defaults()
+	for _, opt := range opts {
+		opt(&s)
+	}
+	var errs []error
+	for _, name := range s.used {
+		if !allowed[name] {
+			errs = append(errs, fmt.Errorf("model: %s has no %s", typeName, name))
+		}
+	}
+	return s, errors.Join(errs...)
+}
+
+// NewPerson returns a validated Person. Only WithAge applies to a Person.
+//
+//	p, err := model.NewPerson("Ann", model.WithAge(30))
+func NewPerson(name string, opts ...Option) (Person, error) {
+	s, err := apply("Person", map[string]bool{"age": true}, opts)
+	p := Person{Name: name, Age: s.age}
+	if err = errors.Join(err, p.Validate()); err != nil {
+		return Person{}, err
+	}
+	return p, nil
+}
+
+// NewPerson2 returns a validated Person2. WithAddress is required.
+//
+//	p, err := model.NewPerson2("Rob",
+//		model.WithAge(25),
+//		model.WithAddress(model.Address{City: "New York", Country: "USA"}),
+//		model.WithCell("987-654-3210"))
+func NewPerson2(name string, opts ...Option) (Person2, error) {
+	s, err := apply("Person2", map[string]bool{"age": true, "address": true, "home": true, "cell": true}, opts)
+	p := Person2{Name: name, Age: s.age, Address: s.address, PhoneHomeCell: s.phone}
+	if err = errors.Join(err, p.Validate()); err != nil {
+		return Person2{}, err
+	}
+	return p, nil
+}
+
+// NewEmployee returns a validated Employee. WithAddress is required.
+func NewEmployee(name string, opts ...Option) (Employee, error) {
+	s, err := apply("Employee", map[string]bool{"age": true, "address": true}, opts)
+	e := Employee{Name: name, Age: s.age, Address: s.address}
+	if err = errors.Join(err, e.Validate()); err != nil {
+		return Employee{}, err
+	}
+	return e, nil
+}
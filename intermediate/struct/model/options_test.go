@@ -0,0 +1,125 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var london = Address{City: "London", Country: "UK"}
+
+func TestDefaults(t *testing.T) {
+	p, err := NewPerson("Ann")
+	if err != nil || p.Age != DefaultAge {
+		t.Errorf("NewPerson without options = %+v, %v; want age %d", p, err, DefaultAge)
+	}
+	e, err := NewEmployee("Dev", WithAddress(london))
+	if err != nil || e.Age != DefaultAge {
+		t.Errorf("NewEmployee without WithAge = %+v, %v; want age %d", e, err, DefaultAge)
+	}
+	b, err := NewBuilder("Ann").Person()
+	if err != nil || b != p {
+		t.Errorf("Builder without options = %+v, %v; want %+v", b, err, p)
+	}
+}
+
+func TestOptionsOverrideDefaults(t *testing.T) {
+	p, err := NewPerson2("Rob", WithAge(25), WithAddress(london), WithHome("555-0100"), WithCell("555-0199"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Person2{Name: "Rob", Age: 25, Address: london, PhoneHomeCell: PhoneHomeCell{"555-0100", "555-0199"}}
+	if p != want {
+		t.Errorf("NewPerson2 = %+v, want %+v", p, want)
+	}
+	// Later options win over earlier ones
+	if p, _ := NewPerson("Ann", WithAge(30), WithAge(MinAge)); p.Age != MinAge {
+		t.Errorf("age after two WithAge = %d, want %d", p.Age, MinAge)
+	}
+}
+
+func TestOptionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"option the type lacks", func() error {
+			_, err := NewEmployee("Dev", WithAddress(london), WithCell("555-0100"))
+			return err
+		}(), []string{"Employee has no cell"}},
+		{"every invalid field", func() error {
+			_, err := NewPerson2("", WithAge(200), WithCell("call me"))
+			return err
+		}(), []string{"name: must not be empty", "age: must be between", "address.city:", "address.country:", "phone.cell:"}},
+	}
+	for _, tt := range tests {
+		if tt.err == nil {
+			t.Errorf("%s: no error", tt.name)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(tt.err.Error(), want) {
+				t.Errorf("%s: error %q does not mention %q", tt.name, tt.err, want)
+			}
+		}
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	base := NewBuilder("").Address("London", "UK")
+	alice, err := base.Name("Alice").Age(34).Employee()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Deriving alice must not have changed base, and vice versa
+	bob, err := base.Name("Bob").Employee()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alice != (Employee{Name: "Alice", Age: 34, Address: london}) {
+		t.Errorf("alice = %+v", alice)
+	}
+	if bob != (Employee{Name: "Bob", Age: DefaultAge, Address: london}) {
+		t.Errorf("bob = %+v", bob)
+	}
+
+	// Two builders appended to from the same template keep their own options
+	withCell := base.Name("C").Cell("555-0100")
+	withHome := base.Name("H").Home("555-0199")
+	c, err1 := withCell.Person2()
+	h, err2 := withHome.Person2()
+	if err := errors.Join(err1, err2); err != nil {
+		t.Fatal(err)
+	}
+	if c.PhoneHomeCell != (PhoneHomeCell{Cell: "555-0100"}) || h.PhoneHomeCell != (PhoneHomeCell{Home: "555-0199"}) {
+		t.Errorf("builders shared options: %+v, %+v", c.PhoneHomeCell, h.PhoneHomeCell)
+	}
+}
+
+func TestBuilderValidates(t *testing.T) {
+	_, err := NewBuilder("").Age(-1).Address("", "UK").Employee()
+	if got, want := strings.Join(fieldsOf(err), ","), "name,age,city"; got != want {
+		t.Errorf("Builder reported fields %s, want %s (error %v)", got, want, err)
+	}
+	if _, err := NewBuilder("Ann").Cell("555-0100").Person(); err == nil {
+		t.Error("Builder accepted a cell number for a Person")
+	}
+}
+
+// fieldsOf returns the Field of every FieldError in err, looking inside
+// errors joined at any depth.
+func fieldsOf(err error) []string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var fields []string
+		for _, e := range joined.Unwrap() {
+			fields = append(fields, fieldsOf(e)...)
+		}
+		return fields
+	}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return []string{fe.Field}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"intermediate/struct/validate"
+)
+
+func init() {
+	validate.Register("age", AgeRule)
+	validate.Register("phone", PhoneRule)
+}
+
+// FieldError describes one invalid field. Field is the path to it, such as
+// "address.city".
+type FieldError struct {
+	Field string
+	Msg   string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Msg
+}
+
+// phonePattern accepts an optional leading +, then groups of digits. The
+// groups are separated by a single space, dash or dot, or by a parenthesis
+// with an optional space on its outer side, as in "+1 (555) 010-0100".
+var phonePattern = regexp.MustCompile(`^\+?[0-9]+(([ .\-]| ?\(|\) ?)[0-9]+)*$`)
+
+// checkName reports an error if name is blank.
+func checkName(field, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return &FieldError{field, "must not be empty"}
+	}
+	return nil
+}
+
+// checkAge reports an error if age is outside [MinAge, MaxAge].
+func checkAge(field string, age int) error {
+	if age < MinAge || age > MaxAge {
+		return &FieldError{field, fmt.Sprintf("must be between %d and %d, got %d", MinAge, MaxAge, age)}
+	}
+	return nil
+}
+
+// ValidPhone reports whether s is a plausible phone number of 7 to 15 digits.
+func ValidPhone(s string) bool {
+	digits := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	return phonePattern.MatchString(s) && digits >= 7 && digits <= 15
+}
+
+// AgeRule is the validate rule behind the "age" tag. It checks the same
+// [MinAge, MaxAge] range as Validate, so the limits live in one place.
+// Like PhoneRule, it is registered with the default Validator when this
+// package is loaded.
+func AgeRule(v reflect.Value, _ string) error {
+	if !v.CanInt() {
+		return fmt.Errorf("%w: cannot check a %s as an age", validate.ErrBadTag, v.Kind())
+	}
+	if age := v.Int(); age < MinAge || age > MaxAge {
+		return fmt.Errorf("must be between %d and %d, got %d", MinAge, MaxAge, age)
+	}
+	return nil
+}
+
+// PhoneRule is the validate rule behind the "phone" tag on PhoneHomeCell.
+// It is registered with the default Validator when this package is loaded;
+// register it yourself on a Validator made with validate.New.
+func PhoneRule(v reflect.Value, _ string) error {
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("%w: cannot check a %s as a phone number", validate.ErrBadTag, v.Kind())
+	}
+	if !ValidPhone(v.String()) {
+		return fmt.Errorf("%q is not a valid phone number", v.String())
+	}
+	return nil
+}
+
+// checkPhone reports an error if phone is set but not valid.
+func checkPhone(field, phone string) error {
+	if phone != "" && !ValidPhone(phone) {
+		return &FieldError{field, fmt.Sprintf("%q is not a valid phone number", phone)}
+	}
+	return nil
+}
+
+// nest prefixes the field path of every FieldError in err with parent.
+func nest(parent string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+	nested := make([]error, len(errs))
+	for i, e := range errs {
+		var fe *FieldError
+		if errors.As(e, &fe) {
+			e = &FieldError{parent + "." + fe.Field, fe.Msg}
+		}
+		nested[i] = e
+	}
+	return errors.Join(nested...)
+}
+
+// Validate reports every invalid field of p, joined with errors.Join.
+func (p Person) Validate() error {
+	return errors.Join(
+		checkName("name", p.Name),
+		checkAge("age", p.Age),
+	)
+}
+
+// Validate reports every invalid field of a, joined with errors.Join.
+func (a Address) Validate() error {
+	return errors.Join(
+		checkName("city", a.City),
+		checkName("country", a.Country),
+	)
+}
+
+// Validate reports every invalid field of p, joined with errors.Join.
+func (p PhoneHomeCell) Validate() error {
+	return errors.Join(
+		checkPhone("home", p.Home),
+		checkPhone("cell", p.Cell),
+	)
+}
+
+// Validate reports every invalid field of p, including those of its
+// address and phone numbers, joined with errors.Join.
+func (p Person2) Validate() error {
+	return errors.Join(
+		checkName("name", p.Name),
+		checkAge("age", p.Age),
+		nest("address", p.Address.Validate()),
+		nest("phone", p.PhoneHomeCell.Validate()),
+	)
+}
+
+// Validate reports every invalid field of e, joined with errors.Join.
+// The embedded address's fields are promoted, so they are reported
+// without an "address." prefix.
+func (e Employee) Validate() error {
+	return errors.Join(
+		checkName("name", e.Name),
+		checkAge("age", e.Age),
+		e.Address.Validate(),
+	)
+}
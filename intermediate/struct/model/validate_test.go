@@ -0,0 +1,67 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"intermediate/struct/validate"
+)
+
+func TestPhoneRules(t *testing.T) {
+	tests := []struct {
+		phone string
+		ok    bool
+	}{
+		{"", true},
+		{"555-0100", true},
+		{"+44 20 7946 0958", true},
+		{"+1 (555) 010-0100", true},
+		{"12345", false},
+		{"1234567890123456", false},
+		{"555.010.0100", true},
+		{"555--0100", false},
+		{"555 - 0100", false},
+		{"+1 (555)-010-0100", false},
+		{"+1 ((555)) 0100", false},
+		{"call me", false},
+	}
+	for _, tt := range tests {
+		p := PhoneHomeCell{Home: tt.phone}
+		// The method and the tag-driven validator must agree
+		if err := p.Validate(); (err == nil) != tt.ok {
+			t.Errorf("Validate(%q) = %v, want ok=%t", tt.phone, err, tt.ok)
+		}
+		err := validate.Struct(p)
+		if (err == nil) != tt.ok {
+			t.Errorf("validate.Struct(%q) = %v, want ok=%t", tt.phone, err, tt.ok)
+		}
+		var fe *validate.FieldError
+		if err != nil && (!errors.As(err, &fe) || fe.Path != "home" || fe.Rule != "phone") {
+			t.Errorf("validate.Struct(%q) = %v, want a phone error on home", tt.phone, err)
+		}
+	}
+}
+
+func TestAgeRules(t *testing.T) {
+	for _, age := range []int{MinAge - 1, MinAge, 30, MaxAge, MaxAge + 1} {
+		ok := age >= MinAge && age <= MaxAge
+		p := Person{Name: "Ann", Age: age}
+		if err := p.Validate(); (err == nil) != ok {
+			t.Errorf("Validate(age %d) = %v, want ok=%t", age, err, ok)
+		}
+		// The "age" tag must enforce the same limits as the constants
+		err := validate.Struct(p)
+		if (err == nil) != ok {
+			t.Errorf("validate.Struct(age %d) = %v, want ok=%t", age, err, ok)
+		}
+		var fe *validate.FieldError
+		if err != nil && (!errors.As(err, &fe) || fe.Path != "age" || fe.Rule != "age") {
+			t.Errorf("validate.Struct(age %d) = %v, want an age error on age", age, err)
+		}
+	}
+	if err := validate.Struct(struct {
+		Age string `validate:"age"`
+	}{"ten"}); !errors.Is(err, validate.ErrBadTag) {
+		t.Errorf("age rule on a string: error = %v, want ErrBadTag", err)
+	}
+}
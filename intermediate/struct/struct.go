@@ -1,6 +1,15 @@
 package main
 
-import "fmt"
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "intermediate/struct/directory"
+    "intermediate/struct/model"
+    "intermediate/struct/structdiff"
+    "intermediate/struct/validate"
+)
 
 // Person struct represents a person with a name and age
 type Person struct {
@@ -146,6 +155,54 @@ func main() {
     fmt.Println("emp1 == emp2:", emp1 == emp2) // true: all fields (including embedded) are equal
     fmt.Println("emp1 == emp3:", emp1 == emp3) // false: Address fields differ
 
+    // == only says whether they differ; structdiff says where
+    if changes, err := structdiff.Diff(emp1, emp3); err == nil {
+        fmt.Print(changes)
+    }
+
+    // --- Exported, validated versions: the model package ---
+    // Struct tags control the JSON field names, and unlike p2.address every
+    // field of model.Person2 is exported, so encoders can see it.
+    // Functional options replace the nested composite literal
+    rob, err := model.NewPerson2("Rob",
+        model.WithAge(25),
+        model.WithAddress(model.Address{City: "New York", Country: "USA"}),
+        model.WithHome("123-456-7890"),
+        model.WithCell("987-654-3210"))
+    if err != nil {
+        fmt.Println(err)
+    }
+    data, _ := json.Marshal(rob)
+    fmt.Println(string(data))
+
+    // A builder does the same fluently; each step returns a copy, so a
+    // partly built one can be reused as a template
+    londoner := model.NewBuilder("").Address("London", "UK")
+    ann, _ := londoner.Name("Ann").Age(41).Employee()
+    ben, _ := londoner.Name("Ben").Age(29).Employee()
+    fmt.Println(ann, ben)
+
+    // Validate reports every problem at once, not just the first
+    if _, err := model.NewEmployee("", model.WithAge(200), model.WithAddress(model.Address{City: "London"})); err != nil {
+        fmt.Println("invalid employee:")
+        fmt.Println(err)
+    }
+
+    // The same rules are written in the validate tags, so a generic,
+    // reflection-based validator can check any tagged struct
+    if err := validate.Struct(model.Employee{Name: "Dana", Age: -1}); err != nil {
+        fmt.Println("validate.Struct:")
+        fmt.Println(err)
+    }
+
+    // A directory keeps many employees indexed by city, country and age
+    dir := directory.New()
+    dir.ImportCSV(strings.NewReader("name,age,city,country\n" +
+        "Alice,34,London,UK\nBob,52,London,UK\nCara,41,Paris,France\nDev,38,London,UK\n"))
+    for _, r := range dir.Where(directory.City("London")).AndAgeBetween(30, 50).OrderBy(directory.ByName).All() {
+        fmt.Println(r.ID, r.Name, r.Age)
+    }
+
     // Revision Notes:
     // - Anonymous struct: a struct defined and used inline, not as a named type.
     // - Anonymous struct field (embedding): a struct field declared with only the type, not a name.
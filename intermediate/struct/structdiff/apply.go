@@ -0,0 +1,167 @@
+package structdiff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ErrBadPath is returned by Apply when a path does not lead anywhere in the target.
+var ErrBadPath = errors.New("structdiff: path not found")
+
+// Apply applies the changes in p, in order, to the value target points to.
+//
+// Values are converted to the type at their path through encoding/json, so a
+// Patch that was itself decoded from JSON, where numbers are float64 and
+// structs are maps, applies just as well as one fresh from Diff.
+func Apply(target any, p Patch) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("structdiff: Apply needs a non-nil pointer, got %T", target)
+	}
+	for _, c := range p {
+		if err := apply(v.Elem(), segments(c.Path), c); err != nil {
+			return fmt.Errorf("%s %s: %w", c.Op, c.Path, err)
+		}
+	}
+	return nil
+}
+
+// apply walks v along segs and performs c at the end. v must be settable.
+func apply(v reflect.Value, segs []string, c Change) error {
+	if len(segs) == 0 {
+		if c.Op == OpRemove {
+			v.SetZero()
+			return nil
+		}
+		return setJSON(v, c.Value)
+	}
+	seg, rest := segs[0], segs[1:]
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return apply(v.Elem(), segs, c)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return ErrBadPath
+		}
+		// The dynamic value is not settable in place, so work on a copy
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := apply(elem, segs, c); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+
+	case reflect.Struct:
+		for _, f := range fields(v.Type()) {
+			if f.name == seg {
+				return apply(v.FieldByIndex(f.index), rest, c)
+			}
+		}
+		return fmt.Errorf("%w: no field %q in %s", ErrBadPath, seg, v.Type())
+
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(seg)
+		if err != nil || i < 0 || i > v.Len() {
+			return fmt.Errorf("%w: bad index %q", ErrBadPath, seg)
+		}
+		if len(rest) == 0 && v.Kind() == reflect.Slice {
+			switch c.Op {
+			case OpAdd:
+				elem := reflect.New(v.Type().Elem()).Elem()
+				if err := setJSON(elem, c.Value); err != nil {
+					return err
+				}
+				// Grow by one, shift the tail right and drop elem into the gap
+				v.Set(reflect.Append(v, elem))
+				reflect.Copy(v.Slice(i+1, v.Len()), v.Slice(i, v.Len()-1))
+				v.Index(i).Set(elem)
+				return nil
+			case OpRemove:
+				if i == v.Len() {
+					return fmt.Errorf("%w: index %d out of range", ErrBadPath, i)
+				}
+				v.Set(reflect.AppendSlice(v.Slice(0, i), v.Slice(i+1, v.Len())))
+				return nil
+			}
+		}
+		if i == v.Len() {
+			return fmt.Errorf("%w: index %d out of range", ErrBadPath, i)
+		}
+		return apply(v.Index(i), rest, c)
+
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		if err := setKey(key, seg); err != nil {
+			return err
+		}
+		if len(rest) == 0 && c.Op == OpRemove {
+			v.SetMapIndex(key, reflect.Value{})
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		// Map elements are not addressable either, so edit a copy and store it back
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if old := v.MapIndex(key); old.IsValid() {
+			elem.Set(old)
+		} else if len(rest) > 0 {
+			return fmt.Errorf("%w: no key %q", ErrBadPath, seg)
+		}
+		if err := apply(elem, rest, c); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+	return fmt.Errorf("%w: cannot descend into %s", ErrBadPath, v.Type())
+}
+
+// setJSON stores value in v, converting it through its JSON form. A struct
+// with no JSON form of its own, such as one with only unexported fields,
+// would lose its state that way, so a value of exactly v's type is stored
+// as it is.
+func setJSON(v reflect.Value, value any) error {
+	if rv := reflect.ValueOf(value); rv.IsValid() && rv.Type() == v.Type() &&
+		v.Kind() == reflect.Struct && opaque(v.Type()) && !marshals(v.Type()) {
+		v.Set(rv)
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	ptr := reflect.New(v.Type())
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return err
+	}
+	v.Set(ptr.Elem())
+	return nil
+}
+
+// setKey parses a path segment into the map key k.
+func setKey(k reflect.Value, seg string) error {
+	if k.Kind() == reflect.String {
+		k.SetString(seg)
+		return nil
+	}
+	// Numbers and other JSON scalars parse as themselves; anything else is
+	// tried as a JSON string
+	if err := json.Unmarshal([]byte(seg), k.Addr().Interface()); err == nil {
+		return nil
+	}
+	data, _ := json.Marshal(seg)
+	if err := json.Unmarshal(data, k.Addr().Interface()); err != nil {
+		return fmt.Errorf("%w: bad map key %q for %s", ErrBadPath, seg, k.Type())
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+// Package structdiff reports the differences between two values of the same
+// type, field by field, and can replay them onto another value.
+//
+// A difference is a Change in the style of JSON Patch (RFC 6902): an
+// operation, a JSON Pointer path such as "/address/city", and the new value.
+// Paths use each field's JSON name where it has one, and fields of embedded
+// structs are promoted just as encoding/json does, so a Patch marshals to
+// JSON that reads naturally next to the JSON of the values themselves.
+//
+// Only exported fields are compared. Structs that hide their state, such as
+// time.Time, are compared whole instead: see Diff. Functions and channels
+// are ignored.
+package structdiff
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Op is the kind of a Change.
+type Op string
+
+const (
+	OpAdd     Op = "add"     // a slice element or map entry that only the new value has
+	OpRemove  Op = "remove"  // a slice element or map entry that only the old value has
+	OpReplace Op = "replace" // a value present in both that differs
+)
+
+// Change is one difference between two values. Old is not part of JSON
+// Patch; it is kept so that a Patch can be shown to people.
+type Change struct {
+	Op    Op     `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+	Old   any    `json:"old,omitempty"`
+}
+
+// Patch is an ordered list of changes. Applying them in order to the old
+// value produces the new one.
+type Patch []Change
+
+// ErrTypeMismatch is returned by Diff when the two values have different types.
+var ErrTypeMismatch = errors.New("structdiff: values have different types")
+
+// Diff returns the changes that turn a into b. Both must have the same type.
+// An empty Patch means the values are equal, even where == would not compile.
+//
+// A struct with no exported fields, or with an Equal method or a MarshalJSON
+// method, is compared as a single value: by Equal if it has one, otherwise
+// by its JSON or, failing that, reflect.DeepEqual. A difference is reported
+// as one replace of the whole struct.
+func Diff(a, b any) (Patch, error) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		if va.IsValid() || vb.IsValid() {
+			return nil, fmt.Errorf("%w: %T and %T", ErrTypeMismatch, a, b)
+		}
+		return nil, nil
+	}
+	if va.Type() != vb.Type() {
+		return nil, fmt.Errorf("%w: %T and %T", ErrTypeMismatch, a, b)
+	}
+	var d differ
+	d.diff(va, vb, "")
+	return d.patch, nil
+}
+
+type differ struct {
+	patch Patch
+}
+
+func (d *differ) add(op Op, path string, old, new reflect.Value) {
+	c := Change{Op: op, Path: path}
+	if old.IsValid() {
+		c.Old = old.Interface()
+	}
+	if new.IsValid() {
+		c.Value = new.Interface()
+	}
+	d.patch = append(d.patch, c)
+}
+
+func (d *differ) diff(a, b reflect.Value, path string) {
+	switch a.Kind() {
+	case reflect.Struct:
+		if opaque(a.Type()) {
+			if !equalOpaque(a, b) {
+				d.add(OpReplace, path, a, b)
+			}
+			return
+		}
+		for _, f := range fields(a.Type()) {
+			d.diff(a.FieldByIndex(f.index), b.FieldByIndex(f.index), path+"/"+escape(f.name))
+		}
+
+	case reflect.Pointer, reflect.Interface:
+		switch {
+		case a.IsNil() && b.IsNil():
+		case a.IsNil() || b.IsNil() || a.Elem().Type() != b.Elem().Type():
+			d.add(OpReplace, path, a, b)
+		default:
+			d.diff(a.Elem(), b.Elem(), path)
+		}
+
+	case reflect.Slice, reflect.Array:
+		common := min(a.Len(), b.Len())
+		for i := range common {
+			d.diff(a.Index(i), b.Index(i), path+"/"+strconv.Itoa(i))
+		}
+		for i := common; i < b.Len(); i++ {
+			d.add(OpAdd, path+"/"+strconv.Itoa(i), reflect.Value{}, b.Index(i))
+		}
+		// Remove from the end so earlier indexes stay valid while applying
+		for i := a.Len() - 1; i >= common; i-- {
+			d.add(OpRemove, path+"/"+strconv.Itoa(i), a.Index(i), reflect.Value{})
+		}
+
+	case reflect.Map:
+		for _, k := range sortedKeys(a, b) {
+			key := path + "/" + escape(fmt.Sprint(k.Interface()))
+			va, vb := a.MapIndex(k), b.MapIndex(k)
+			switch {
+			case !vb.IsValid():
+				d.add(OpRemove, key, va, reflect.Value{})
+			case !va.IsValid():
+				d.add(OpAdd, key, reflect.Value{}, vb)
+			default:
+				d.diff(va, vb, key)
+			}
+		}
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+
+	default:
+		if !a.Equal(b) {
+			d.add(OpReplace, path, a, b)
+		}
+	}
+}
+
+var (
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+	boolType      = reflect.TypeFor[bool]()
+)
+
+// opaque reports whether the struct type t should be compared whole rather
+// than field by field.
+func opaque(t reflect.Type) bool {
+	return len(fields(t)) == 0 || hasEqual(t) || marshals(t)
+}
+
+// hasEqual reports whether t has a method Equal(t) bool, like time.Time.
+func hasEqual(t reflect.Type) bool {
+	m, ok := t.MethodByName("Equal")
+	return ok && m.Type.NumIn() == 2 && m.Type.In(1) == t &&
+		m.Type.NumOut() == 1 && m.Type.Out(0) == boolType
+}
+
+// marshals reports whether t, or a pointer to it, implements json.Marshaler.
+func marshals(t reflect.Type) bool {
+	return t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType)
+}
+
+// equalOpaque compares two values of an opaque struct type.
+func equalOpaque(a, b reflect.Value) bool {
+	t := a.Type()
+	switch {
+	case hasEqual(t):
+		m, _ := t.MethodByName("Equal")
+		return m.Func.Call([]reflect.Value{a, b})[0].Bool()
+	case marshals(t):
+		ja, errA := json.Marshal(addressable(a).Interface())
+		jb, errB := json.Marshal(addressable(b).Interface())
+		if errA == nil && errB == nil {
+			return bytes.Equal(ja, jb)
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// addressable returns a pointer to a copy of v, so that methods with
+// pointer receivers, such as a MarshalJSON on *T, are found.
+func addressable(v reflect.Value) reflect.Value {
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// field is an exported struct field, located by its index path so that
+// promoted fields of embedded structs are found too.
+type field struct {
+	name  string
+	index []int
+}
+
+// fields lists the fields of t in the order encoding/json would, with
+// embedded structs without a JSON name flattened into their parent.
+func fields(t reflect.Type) []field {
+	var out []field
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct && f.IsExported() {
+			for _, inner := range fields(f.Type) {
+				out = append(out, field{inner.name, append([]int{i}, inner.index...)})
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		out = append(out, field{name, []int{i}})
+	}
+	return out
+}
+
+// sortedKeys returns the keys of both maps, once each, in a stable order.
+func sortedKeys(a, b reflect.Value) []reflect.Value {
+	seen := make(map[any]bool)
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{a, b} {
+		for _, k := range m.MapKeys() {
+			if !seen[k.Interface()] {
+				seen[k.Interface()] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	slices.SortFunc(keys, func(x, y reflect.Value) int {
+		return cmp.Compare(fmt.Sprint(x.Interface()), fmt.Sprint(y.Interface()))
+	})
+	return keys
+}
+
+// escape encodes a JSON Pointer segment.
+func escape(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// unescape decodes a JSON Pointer segment.
+func unescape(s string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(s)
+}
+
+// segments splits a JSON Pointer into its decoded segments.
+func segments(path string) []string {
+	if path == "" {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, p := range parts {
+		parts[i] = unescape(p)
+	}
+	return parts
+}
+
+// String renders the patch for people, one change per line:
+//
+//	~ address.city: "London" -> "Paris"
+//	+ tags.2: "new"
+//	- scores.math: 90
+func (p Patch) String() string {
+	var b strings.Builder
+	for _, c := range p {
+		path := strings.Join(segments(c.Path), ".")
+		if path == "" {
+			path = "(root)"
+		}
+		switch c.Op {
+		case OpAdd:
+			fmt.Fprintf(&b, "+ %s: %#v\n", path, c.Value)
+		case OpRemove:
+			fmt.Fprintf(&b, "- %s: %#v\n", path, c.Old)
+		default:
+			fmt.Fprintf(&b, "~ %s: %#v -> %#v\n", path, c.Old, c.Value)
+		}
+	}
+	return b.String()
+}
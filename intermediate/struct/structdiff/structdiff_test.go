@@ -0,0 +1,171 @@
+package structdiff
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type Base struct {
+	ID int `json:"id"`
+}
+
+// token hides its state, so it can only be compared whole
+type token struct{ secret string }
+
+type record struct {
+	Base
+	Name    string           `json:"name"`
+	Address *address         `json:"address,omitempty"`
+	Tags    []string         `json:"tags"`
+	Scores  map[string]int   `json:"scores"`
+	Seen    time.Time        `json:"seen"`
+	Token   token            `json:"token"`
+	Extra   any              `json:"extra,omitempty"`
+	Nested  map[string][]int `json:"nested,omitempty"`
+	Skip    func()           `json:"-"`
+	Labels  map[int]string   `json:"labels,omitempty"`
+	Grid    [2][2]int        `json:"grid"`
+	private string
+}
+
+func sample() record {
+	return record{
+		Base:    Base{ID: 1},
+		Name:    "Ann",
+		Address: &address{"London", "UK"},
+		Tags:    []string{"a", "b", "c"},
+		Scores:  map[string]int{"math": 90, "art": 70},
+		Seen:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Token:   token{"x"},
+	}
+}
+
+func TestDiffApplyRoundTrip(t *testing.T) {
+	edits := map[string]func(*record){
+		"nothing":          func(r *record) {},
+		"promoted field":   func(r *record) { r.ID = 2 },
+		"nested field":     func(r *record) { r.Address.City = "Paris" },
+		"nil pointer":      func(r *record) { r.Address = nil },
+		"grow slice":       func(r *record) { r.Tags = append(r.Tags, "d", "e") },
+		"shrink slice":     func(r *record) { r.Tags = r.Tags[:1] },
+		"empty slice":      func(r *record) { r.Tags = nil },
+		"map entries":      func(r *record) { r.Scores = map[string]int{"math": 95, "music": 80} },
+		"nil map":          func(r *record) { r.Scores = nil },
+		"time":             func(r *record) { r.Seen = r.Seen.Add(time.Hour) },
+		"opaque struct":    func(r *record) { r.Token = token{"y"} },
+		"interface":        func(r *record) { r.Extra = "hello" },
+		"nested map slice": func(r *record) { r.Nested = map[string][]int{"k": {1, 2}} },
+		"int keys":         func(r *record) { r.Labels = map[int]string{7: "seven"} },
+		"array":            func(r *record) { r.Grid[1][0] = 5 },
+		"everything": func(r *record) {
+			r.Name, r.Address.Country, r.Tags[1] = "Bob", "FR", "z"
+			r.Scores["art"]++
+			r.Seen = time.Time{}
+		},
+	}
+	for name, edit := range edits {
+		old, new := sample(), sample()
+		edit(&new)
+		p, err := Diff(old, new)
+		if err != nil {
+			t.Fatalf("%s: Diff: %v", name, err)
+		}
+		if name == "nothing" && len(p) != 0 {
+			t.Errorf("%s: Diff = %v, want no changes", name, p)
+		}
+		if err := Apply(&old, p); err != nil {
+			t.Fatalf("%s: Apply: %v", name, err)
+		}
+		if rest, _ := Diff(old, new); len(rest) != 0 {
+			t.Errorf("%s: after Apply, still differs:\n%s", name, rest)
+		}
+	}
+}
+
+func TestApplyDecodedPatch(t *testing.T) {
+	old, new := sample(), sample()
+	new.Address.City = "Paris"
+	new.Tags = append(new.Tags, "d")
+	delete(new.Scores, "art")
+	new.Seen = new.Seen.Add(time.Minute)
+	p, _ := Diff(old, new)
+
+	// A patch sent as JSON comes back with float64 numbers and plain strings
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Patch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(&old, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if rest, _ := Diff(old, new); len(rest) != 0 {
+		t.Errorf("after Apply, still differs:\n%s", rest)
+	}
+}
+
+func TestDiffOpaque(t *testing.T) {
+	a := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	// The same instant in another zone is Equal, so there is nothing to report
+	if p, _ := Diff(a, a.In(time.FixedZone("X", 3600))); len(p) != 0 {
+		t.Errorf("same instant: Diff = %v", p)
+	}
+	p, _ := Diff(a, a.Add(time.Second))
+	if len(p) != 1 || p[0].Op != OpReplace || p[0].Path != "" {
+		t.Fatalf("Diff of times = %v, want one replace at the root", p)
+	}
+	if got := p[0].Value.(time.Time); !got.Equal(a.Add(time.Second)) {
+		t.Errorf("replace value = %v", got)
+	}
+
+	p, _ = Diff(sample(), func() record { r := sample(); r.Seen = r.Seen.Add(1); return r }())
+	if len(p) != 1 || p[0].Path != "/seen" {
+		t.Errorf("Diff of records = %v, want one change at /seen", p)
+	}
+}
+
+func TestDiffInvalid(t *testing.T) {
+	if p, err := Diff(nil, nil); err != nil || len(p) != 0 {
+		t.Errorf("Diff(nil, nil) = %v, %v", p, err)
+	}
+	if _, err := Diff(nil, 1); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Diff(nil, 1) error = %v, want ErrTypeMismatch", err)
+	}
+	if _, err := Diff(1, nil); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Diff(1, nil) error = %v, want ErrTypeMismatch", err)
+	}
+	if _, err := Diff(1, "1"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Diff(1, \"1\") error = %v, want ErrTypeMismatch", err)
+	}
+}
+
+func TestApplyBadPath(t *testing.T) {
+	r := sample()
+	for _, p := range []Patch{
+		{{Op: OpReplace, Path: "/nope", Value: 1}},
+		{{Op: OpReplace, Path: "/tags/9", Value: "x"}},
+		{{Op: OpReplace, Path: "/tags/x", Value: "x"}},
+		{{Op: OpReplace, Path: "/scores/none/deeper", Value: 1}},
+	} {
+		if err := Apply(&r, p); !errors.Is(err, ErrBadPath) {
+			t.Errorf("Apply(%v) error = %v, want ErrBadPath", p, err)
+		}
+	}
+	if err := Apply(r, nil); err == nil {
+		t.Error("Apply to a non-pointer succeeded")
+	}
+	if !reflect.DeepEqual(r, sample()) {
+		t.Error("failed patches changed the target")
+	}
+}
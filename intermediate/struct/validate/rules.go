@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var builtinRules = map[string]Rule{
+	"required": required,
+	"min":      bound("at least", func(got, limit float64) bool { return got >= limit }),
+	"max":      bound("at most", func(got, limit float64) bool { return got <= limit }),
+	"len":      bound("exactly", func(got, limit float64) bool { return got == limit }),
+	"oneof":    oneOf,
+	"email":    email,
+}
+
+// required fails for zero values and for strings that are only whitespace.
+func required(v reflect.Value, _ string) error {
+	if v.IsZero() || (v.Kind() == reflect.String && strings.TrimSpace(v.String()) == "") {
+		return errors.New("is required")
+	}
+	return nil
+}
+
+// size returns what min, max and len compare: the value of a number, the
+// number of characters in a string, or the length of a collection.
+func size(v reflect.Value) (float64, string, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "", nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), "", nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), "", nil
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), " characters", nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), " items", nil
+	}
+	return 0, "", fmt.Errorf("%w: cannot measure a %s", ErrBadTag, v.Kind())
+}
+
+// bound builds min, max and len, which differ only in how they compare.
+func bound(word string, ok func(got, limit float64) bool) Rule {
+	return func(v reflect.Value, param string) error {
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return fmt.Errorf("%w: limit %q is not a number", ErrBadTag, param)
+		}
+		got, unit, err := size(v)
+		if err != nil {
+			return err
+		}
+		if !ok(got, limit) {
+			return fmt.Errorf("must be %s %s%s, got %s", word, param, unit, strconv.FormatFloat(got, 'g', -1, 64))
+		}
+		return nil
+	}
+}
+
+// oneOf fails unless the value, formatted with %v, is one of the
+// space-separated words in param.
+func oneOf(v reflect.Value, param string) error {
+	allowed := strings.Fields(param)
+	// fmt prints the value a reflect.Value holds, even for unexported fields
+	if !slices.Contains(allowed, fmt.Sprint(v)) {
+		return fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+// email fails unless the value is a bare address such as "a@example.com".
+func email(v reflect.Value, _ string) error {
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("%w: cannot check a %s as an email address", ErrBadTag, v.Kind())
+	}
+	addr, err := mail.ParseAddress(v.String())
+	if err != nil || addr.Address != v.String() || addr.Name != "" {
+		return fmt.Errorf("%q is not a valid email address", v.String())
+	}
+	return nil
+}
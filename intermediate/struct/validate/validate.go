@@ -0,0 +1,277 @@
+// Package validate checks struct fields against rules written in their tags:
+//
+//	type Person struct {
+//		Name  string `json:"name" validate:"required"`
+//		Age   int    `json:"age" validate:"min=0,max=150"`
+//		Email string `json:"email" validate:"omitempty,email"`
+//	}
+//
+// Nested structs, pointers to structs, slices of structs and embedded
+// structs are checked too. Errors name the failing field by its dotted path,
+// using the JSON name where there is one, e.g. "address.city" or
+// "items[2].name". Fields of an embedded struct are promoted, exactly as in
+// Go and encoding/json, so an Employee embedding Address reports "city".
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Rule checks a single field value. param is the text after "=" in the tag,
+// or "" if there was none. A non-nil error is reported as the field's
+// message, so it should read well after "<path>: ", e.g. "must be positive".
+// An error wrapping ErrBadTag is a mistake in the tag rather than in the
+// value, such as a limit that is not a number or a rule put on a field of
+// the wrong kind, and is returned by Struct instead.
+type Rule func(v reflect.Value, param string) error
+
+var (
+	// ErrUnknownRule is returned when a tag names a rule that is not registered.
+	ErrUnknownRule = errors.New("validate: unknown rule")
+
+	// ErrBadTag is returned when a rule cannot be applied as written, such
+	// as "min=abc", or "email" on an int.
+	ErrBadTag = errors.New("validate: bad tag")
+
+	// ErrCycle is returned when pointers lead from a struct back to itself.
+	ErrCycle = errors.New("validate: pointer cycle")
+)
+
+// FieldError describes a field that broke a rule.
+type FieldError struct {
+	Path string // dotted path to the field, e.g. "address.city"
+	Rule string // the rule that failed, e.g. "required"
+	Msg  string
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Msg
+}
+
+// Validator holds a set of rules. The zero value is not usable; call New.
+type Validator struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+	plans sync.Map // reflect.Type -> []fieldPlan
+}
+
+// New returns a Validator with the built-in rules registered: required,
+// omitempty, min, max, len, oneof and email.
+func New() *Validator {
+	v := &Validator{rules: make(map[string]Rule)}
+	for name, rule := range builtinRules {
+		v.rules[name] = rule
+	}
+	return v
+}
+
+// Register adds or replaces the rule called name. It is safe to call while
+// other goroutines are validating.
+func (v *Validator) Register(name string, rule Rule) {
+	if name == "" || name == "omitempty" || strings.ContainsAny(name, ",=") {
+		panic(fmt.Sprintf("validate: invalid rule name %q", name))
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[name] = rule
+}
+
+// Struct checks every tagged field reachable from s, which must be a struct
+// or a pointer to one. Every broken rule is reported as a *FieldError, joined
+// with errors.Join, so the caller sees all problems at once. Mistakes in the
+// tags, and pointers that lead back to a struct already being checked, stop
+// the walk and are returned on their own.
+func (v *Validator) Struct(s any) error {
+	w := &walk{v: v, active: make(map[visit]bool)}
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return errors.New("validate: nil pointer")
+		}
+		w.active[visit{rv.Pointer(), rv.Type()}] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: %T is not a struct", s)
+	}
+	if err := w.walkStruct(rv, ""); err != nil {
+		return err
+	}
+	return errors.Join(w.errs...)
+}
+
+var defaultValidator = New()
+
+// Register adds a rule to the default Validator used by Struct.
+func Register(name string, rule Rule) {
+	defaultValidator.Register(name, rule)
+}
+
+// Struct checks s with the default Validator.
+func Struct(s any) error {
+	return defaultValidator.Struct(s)
+}
+
+// fieldPlan is the parsed form of one struct field.
+type fieldPlan struct {
+	index    int
+	name     string // path segment; empty for promoted fields
+	rules    []ruleCall
+	optional bool // omitempty: skip the rules when the value is zero
+}
+
+type ruleCall struct {
+	name, param string
+}
+
+// plan parses the tags of t once and caches the result.
+func (v *Validator) plan(t reflect.Type) []fieldPlan {
+	if p, ok := v.plans.Load(t); ok {
+		return p.([]fieldPlan)
+	}
+	var plans []fieldPlan
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		fp := fieldPlan{index: i, name: fieldName(f)}
+		for _, part := range strings.Split(f.Tag.Get("validate"), ",") {
+			name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch name {
+			case "":
+			case "omitempty":
+				fp.optional = true
+			default:
+				fp.rules = append(fp.rules, ruleCall{name, param})
+			}
+		}
+		plans = append(plans, fp)
+	}
+	v.plans.Store(t, plans)
+	return plans
+}
+
+// fieldName returns the path segment for f: its JSON name if it has one,
+// nothing if it is an embedded struct whose fields are promoted, and
+// otherwise its Go name.
+func fieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name != "" && name != "-" {
+		return name
+	}
+	if t := f.Type; f.Anonymous && (t.Kind() == reflect.Struct ||
+		t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct) {
+		return ""
+	}
+	return f.Name
+}
+
+// rule returns the rule called name. The lock is released before the rule
+// runs, so a rule may itself call Register or Struct.
+func (v *Validator) rule(name string) (Rule, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	rule, ok := v.rules[name]
+	return rule, ok
+}
+
+// walk is the state of one call to Struct.
+type walk struct {
+	v    *Validator
+	errs []error
+	// active holds the pointers followed to reach the current value, so a
+	// pointer back to one of them is caught instead of looping forever
+	active map[visit]bool
+}
+
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// walkStruct checks the fields of rv, appending broken rules to w.errs. It
+// returns an error only for problems with the tags or a pointer cycle.
+func (w *walk) walkStruct(rv reflect.Value, prefix string) error {
+	for _, fp := range w.v.plan(rv.Type()) {
+		fv := rv.Field(fp.index)
+		path := join(prefix, fp.name)
+		if err := w.checkField(fv, fp, path); err != nil {
+			return err
+		}
+		if err := w.descend(fv, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkField runs the rules of one field, stopping at the first that fails.
+func (w *walk) checkField(fv reflect.Value, fp fieldPlan, path string) error {
+	if fp.optional && fv.IsZero() {
+		return nil
+	}
+	for _, rc := range fp.rules {
+		rule, ok := w.v.rule(rc.name)
+		if !ok {
+			return fmt.Errorf("%w %q on field %s", ErrUnknownRule, rc.name, path)
+		}
+		if err := rule(fv, rc.param); err != nil {
+			if errors.Is(err, ErrBadTag) {
+				return fmt.Errorf("rule %q on field %s: %w", rc.name, path, err)
+			}
+			w.errs = append(w.errs, &FieldError{Path: path, Rule: rc.name, Msg: err.Error()})
+			return nil
+		}
+	}
+	return nil
+}
+
+// descend checks the fields of any structs that fv holds or points to.
+func (w *walk) descend(fv reflect.Value, path string) error {
+	switch fv.Kind() {
+	case reflect.Pointer:
+		if fv.IsNil() {
+			return nil
+		}
+		key := visit{fv.Pointer(), fv.Type()}
+		if w.active[key] {
+			return fmt.Errorf("%w at %s", ErrCycle, displayPath(path))
+		}
+		w.active[key] = true
+		defer delete(w.active, key)
+		return w.descend(fv.Elem(), path)
+	case reflect.Struct:
+		return w.walkStruct(fv, path)
+	case reflect.Slice, reflect.Array:
+		for i := range fv.Len() {
+			if err := w.descend(fv.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// displayPath names the top-level struct, whose path is empty, in messages.
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+func join(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case name == "":
+		return prefix
+	}
+	return prefix + "." + name
+}
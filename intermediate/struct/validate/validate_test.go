@@ -0,0 +1,220 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type address struct {
+	City    string `json:"city" validate:"required"`
+	Country string `json:"country,omitempty" validate:"omitempty,len=2"`
+}
+
+type Inner struct {
+	Zip string `json:"zip" validate:"required"`
+}
+
+type item struct {
+	Name string `json:"name" validate:"required"`
+	Qty  int    `validate:"min=1"`
+}
+
+type order struct {
+	ID      int      `json:"id" validate:"min=1"`
+	Email   string   `json:"email" validate:"omitempty,email"`
+	Status  string   `json:"status" validate:"oneof=new paid shipped"`
+	Ship    address  `json:"ship"`
+	Bill    *address `json:"bill"`
+	Items   []item   `json:"items" validate:"min=1"`
+	Extras  [2]*item `json:"extras"`
+	Note    string   `json:"-" validate:"max=5"`
+	private string   `validate:"required"`
+	address          // promoted, unexported embedding
+	*Inner           // promoted through a pointer
+	Tags    []string `json:"tags" validate:"max=2"`
+}
+
+// paths returns the sorted paths of the FieldErrors joined in err.
+func paths(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("error %v is not a joined list", err)
+	}
+	var out []string
+	for _, e := range joined.Unwrap() {
+		var fe *FieldError
+		if !errors.As(e, &fe) {
+			t.Fatalf("error %v is not a *FieldError", e)
+		}
+		out = append(out, fe.Path+" "+fe.Rule)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func validOrder() order {
+	return order{
+		ID:      1,
+		Status:  "new",
+		Ship:    address{City: "Oslo"},
+		Items:   []item{{Name: "pen", Qty: 2}},
+		address: address{City: "Bergen"},
+		Inner:   &Inner{Zip: "0150"},
+	}
+}
+
+func TestStruct(t *testing.T) {
+	if err := New().Struct(validOrder()); err != nil {
+		t.Fatalf("valid order: %v", err)
+	}
+
+	bad := validOrder()
+	bad.ID = 0
+	bad.Email = "not an address"
+	bad.Status = "lost"
+	bad.Ship.Country = "NOR"
+	bad.Bill = &address{}
+	bad.Items = append(bad.Items, item{Qty: 0})
+	bad.Extras[1] = &item{Name: "x"}
+	bad.Note = "too long"
+	bad.address.City = " "
+	bad.Inner.Zip = ""
+	bad.Tags = []string{"a", "b", "c"}
+
+	want := []string{
+		"Note max",
+		"bill.city required",
+		"city required", // from the embedded address
+		"email email",
+		"extras[1].Qty min",
+		"id min",
+		"items[1].Qty min",
+		"items[1].name required", // only the first failing rule per field
+		"ship.country len",
+		"status oneof",
+		"tags max",
+		"zip required", // from the embedded *Inner
+	}
+	if got := paths(t, New().Struct(&bad)); !slices.Equal(got, want) {
+		t.Errorf("errors:\n got  %v\n want %v", got, want)
+	}
+}
+
+func TestStructArguments(t *testing.T) {
+	var nilOrder *order
+	for _, s := range []any{nil, 42, nilOrder, []order{}} {
+		if err := New().Struct(s); err == nil || errors.As(err, new(*FieldError)) {
+			t.Errorf("Struct(%#v) = %v, want an argument error", s, err)
+		}
+	}
+	o := validOrder()
+	pp := &o
+	if err := New().Struct(&pp); err != nil {
+		t.Errorf("Struct(**order) = %v", err)
+	}
+}
+
+type node struct {
+	Name string `validate:"required"`
+	Next *node
+	Kids []*node
+}
+
+func TestStructCycles(t *testing.T) {
+	self := &node{Name: "a"}
+	self.Next = self
+	if err := New().Struct(self); !errors.Is(err, ErrCycle) {
+		t.Errorf("self-referencing node: %v, want ErrCycle", err)
+	}
+
+	a, b := &node{Name: "a"}, &node{Name: "b"}
+	a.Kids, b.Next = []*node{b}, a
+	err := New().Struct(*a) // a by value: the cycle is found one step later
+	if !errors.Is(err, ErrCycle) || !strings.Contains(err.Error(), "Kids[0].Next.Kids[0]") {
+		t.Errorf("a -> b -> a: %v, want ErrCycle at Kids[0].Next.Kids[0]", err)
+	}
+
+	// Sharing a pointer is not a cycle
+	shared := &node{Name: ""}
+	dag := &node{Name: "root", Next: shared, Kids: []*node{shared}}
+	if got := paths(t, New().Struct(dag)); !slices.Equal(got, []string{"Kids[0].Name required", "Next.Name required"}) {
+		t.Errorf("shared pointer: %v", got)
+	}
+}
+
+func TestStructBadTags(t *testing.T) {
+	tests := []struct {
+		name string
+		s    any
+		want error
+	}{
+		{"unknown rule", struct {
+			A string `validate:"nope"`
+		}{}, ErrUnknownRule},
+		{"bad limit", struct {
+			A int `validate:"min=abc"`
+		}{}, ErrBadTag},
+		{"bad limit on a valid value", struct {
+			A string `validate:"max=x"`
+		}{"ok"}, ErrBadTag},
+		{"min on a bool", struct {
+			A bool `validate:"min=1"`
+		}{}, ErrBadTag},
+		{"email on an int", struct {
+			A int `validate:"email"`
+		}{}, ErrBadTag},
+	}
+	for _, tt := range tests {
+		err := New().Struct(tt.s)
+		if !errors.Is(err, tt.want) || errors.As(err, new(*FieldError)) {
+			t.Errorf("%s: %v, want %v alone", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	v := New()
+	type even struct {
+		N int `validate:"even"`
+	}
+	if err := v.Struct(even{3}); !errors.Is(err, ErrUnknownRule) {
+		t.Fatalf("before Register: %v, want ErrUnknownRule", err)
+	}
+	v.Register("even", func(rv reflect.Value, _ string) error {
+		// Rules run without the lock held, so they may use the Validator
+		v.Register("unused", func(reflect.Value, string) error { return nil })
+		if rv.Int()%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+	if err := v.Struct(even{2}); err != nil {
+		t.Errorf("even{2}: %v", err)
+	}
+	var fe *FieldError
+	if err := v.Struct(even{3}); !errors.As(err, &fe) || fe.Error() != "N: must be even" {
+		t.Errorf("even{3}: %v", err)
+	}
+	// Other validators are not affected
+	if err := New().Struct(even{2}); !errors.Is(err, ErrUnknownRule) {
+		t.Errorf("new Validator: %v, want ErrUnknownRule", err)
+	}
+
+	for _, name := range []string{"", "omitempty", "a,b", "a=b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", name)
+				}
+			}()
+			v.Register(name, required)
+		}()
+	}
+}